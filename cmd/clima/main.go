@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/diegoserranor/clima/internal/geonames"
+	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/store"
 	"github.com/diegoserranor/clima/internal/tui"
 )
 
@@ -19,8 +23,77 @@ func main() {
 	)
 
 	debug := flag.Bool("debug", false, "Save logs to file")
+	units := flag.String("units", "", "Units to display: metric, imperial or quantity=unit pairs, e.g. temperature=fahrenheit,wind_speed=kn,precipitation=mm (saved for next runs)")
+	model := flag.String("model", "", "Weather model of the forecast, e.g. best_match, ecmwf_ifs025, gfs_seamless or icon_seamless (saved for next runs)")
+	lat := flag.String("lat", "", "Latitude of the location to show, e.g. 48.8566 (requires --lon)")
+	lon := flag.String("lon", "", "Longitude of the location to show, e.g. 2.3522 (requires --lat)")
+	elevation := flag.String("elevation", "", "Elevation in meters to forecast the --lat/--lon location for, instead of the terrain elevation (saved with the location)")
 	flag.Parse()
 
+	settings, err := store.LoadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load settings: %v\n", err)
+		os.Exit(1)
+	}
+	if *units != "" {
+		if settings.Units, err = openmeteo.ParseUnits(*units); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --units flag: %v\n", err)
+			os.Exit(1)
+		}
+		if err = store.SaveUnits(settings.Units); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save units: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *model != "" {
+		if settings.Model, err = openmeteo.ParseWeatherModel(*model); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --model flag: %v\n", err)
+			os.Exit(1)
+		}
+		err = store.UpdateSettings(func(s *store.Settings) {
+			s.Model = settings.Model
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to save model: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var location *openmeteo.GeocodingResult
+	if *lat != "" || *lon != "" {
+		if *lat == "" || *lon == "" {
+			fmt.Fprintln(os.Stderr, "The --lat and --lon flags must be used together")
+			os.Exit(1)
+		}
+		latitude, err := strconv.ParseFloat(*lat, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --lat flag: %v\n", err)
+			os.Exit(1)
+		}
+		longitude, err := strconv.ParseFloat(*lon, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --lon flag: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := geonames.ReverseGeocode(latitude, longitude)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to resolve coordinates: %v\n", err)
+			os.Exit(1)
+		}
+		if *elevation != "" {
+			meters, err := strconv.ParseFloat(*elevation, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --elevation flag: %v\n", err)
+				os.Exit(1)
+			}
+			result.ElevationOverride = &meters
+		}
+		location = &result
+	} else if *elevation != "" {
+		fmt.Fprintln(os.Stderr, "The --elevation flag requires --lat and --lon")
+		os.Exit(1)
+	}
+
 	if *debug {
 		if err = os.MkdirAll(filepath.Dir(DEBUG_PATH), os.ModePerm); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to ensure debug directory exists: %v\n", err)
@@ -33,8 +106,12 @@ func main() {
 		defer sink.Close()
 	}
 
+	initial := tui.InitialModel(sink, settings)
+	if location != nil {
+		initial = initial.WithLocation(*location)
+	}
 	program := tea.NewProgram(
-		tui.InitialModel(sink),
+		initial,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
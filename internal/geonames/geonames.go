@@ -0,0 +1,167 @@
+// Package geonames resolves places offline from a bundled list of cities.
+//
+// cities.tsv holds a selection of about 500 places from GeoNames
+// (https://www.geonames.org, CC BY 4.0): the main cities of every country and
+// territory, along with a few remote settlements. It is trimmed to the name,
+// first level administrative area, country, coordinates, population and
+// timezone. Far from these places, coordinates are only described as near
+// the closest one. Run go generate to replace it with the full cities15000
+// dump, every place of 15,000 people or more.
+package geonames
+
+//go:generate go run ./internal/gencities
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+)
+
+//go:embed cities.tsv
+var citiesTSV []byte
+
+// Beyond this distance a coordinate is described as near the closest city
+// rather than as the city itself.
+const NEAR_DISTANCE_KM = 25
+
+// A City of the bundled dataset.
+type City struct {
+	Name        string
+	Admin1      string
+	CountryCode string
+	Country     string
+	Latitude    float64
+	Longitude   float64
+	Population  int
+	Timezone    string
+}
+
+// Result converts the city to a geocoding result. The dataset has no GeoNames
+// IDs, so the ID is left at 0.
+func (c City) Result() openmeteo.GeocodingResult {
+	return openmeteo.GeocodingResult{
+		Name:        c.Name,
+		Country:     c.Country,
+		CountryCode: c.CountryCode,
+		Admin1:      c.Admin1,
+		Latitude:    c.Latitude,
+		Longitude:   c.Longitude,
+		Timezone:    c.Timezone,
+		Population:  c.Population,
+		FeatureCode: "PPL",
+	}
+}
+
+type dataset struct {
+	cities []City
+	index  kdTree
+}
+
+// The dataset is parsed and indexed once, on first use.
+var load = sync.OnceValues(func() (dataset, error) {
+	cities, err := parseCities(citiesTSV)
+	if err != nil {
+		return dataset{}, err
+	}
+	return dataset{cities: cities, index: newKDTree(cities)}, nil
+})
+
+// Cities returns every city of the dataset. The slice must not be modified.
+func Cities() ([]City, error) {
+	data, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return data.cities, nil
+}
+
+// Nearest finds the city closest to the given coordinates and its
+// great-circle distance in kilometers.
+func Nearest(latitude, longitude float64) (City, float64, error) {
+	data, err := load()
+	if err != nil {
+		return City{}, 0, err
+	}
+	i, ok := data.index.nearest(latitude, longitude)
+	if !ok {
+		return City{}, 0, fmt.Errorf("no city found near %g, %g", latitude, longitude)
+	}
+	city := data.cities[i]
+	return city, distanceKm(latitude, longitude, city.Latitude, city.Longitude), nil
+}
+
+// ReverseGeocode describes the given coordinates with the nearest city. The
+// result keeps the given coordinates, and is named "Near <city>" when the
+// city is more than NEAR_DISTANCE_KM away. The city population and timezone
+// are only kept when the coordinates are within the city.
+func ReverseGeocode(latitude, longitude float64) (openmeteo.GeocodingResult, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return openmeteo.GeocodingResult{}, fmt.Errorf("latitude %g out of range [-90, 90]", latitude)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return openmeteo.GeocodingResult{}, fmt.Errorf("longitude %g out of range [-180, 180]", longitude)
+	}
+
+	city, distance, err := Nearest(latitude, longitude)
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+
+	result := city.Result()
+	result.Latitude = latitude
+	result.Longitude = longitude
+	if distance > NEAR_DISTANCE_KM {
+		result.Name = "Near " + city.Name
+		result.Population = 0
+		result.Timezone = ""
+		result.FeatureCode = ""
+	}
+	return result, nil
+}
+
+// Parse the tab separated dataset. The first line is the header.
+func parseCities(data []byte) ([]City, error) {
+	var cities []City
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		if line == 1 || scanner.Text() == "" {
+			continue
+		}
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("cities.tsv:%d: expected 8 fields, got %d", line, len(fields))
+		}
+		latitude, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("cities.tsv:%d: latitude: %w", line, err)
+		}
+		longitude, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return nil, fmt.Errorf("cities.tsv:%d: longitude: %w", line, err)
+		}
+		population, err := strconv.Atoi(fields[6])
+		if err != nil {
+			return nil, fmt.Errorf("cities.tsv:%d: population: %w", line, err)
+		}
+		cities = append(cities, City{
+			Name:        fields[0],
+			Admin1:      fields[1],
+			CountryCode: fields[2],
+			Country:     fields[3],
+			Latitude:    latitude,
+			Longitude:   longitude,
+			Population:  population,
+			Timezone:    fields[7],
+		})
+	}
+	return cities, scanner.Err()
+}
@@ -0,0 +1,143 @@
+package geonames
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// The closest city by checking every one of them.
+func bruteForceNearest(t *testing.T, latitude, longitude float64) (City, float64) {
+	t.Helper()
+	cities, err := Cities()
+	if err != nil {
+		t.Fatalf("Cities() error = %v", err)
+	}
+	var best City
+	bestDistance := math.Inf(1)
+	for _, city := range cities {
+		if distance := distanceKm(latitude, longitude, city.Latitude, city.Longitude); distance < bestDistance {
+			best, bestDistance = city, distance
+		}
+	}
+	return best, bestDistance
+}
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      string
+	}{
+		{"exact city", 48.8534, 2.3488, "Paris"},
+		{"west of the antimeridian", -18.2, 179.9, "Suva"},
+		{"east of the antimeridian", -18.2, -179.9, "Suva"},
+		{"north pole", 90, 0, "Longyearbyen"},
+		{"south pole", -90, 0, "McMurdo Station"},
+		{"south pole, other longitude", -90, 180, "McMurdo Station"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city, distance, err := Nearest(tt.latitude, tt.longitude)
+			if err != nil {
+				t.Fatalf("Nearest() error = %v", err)
+			}
+			if city.Name != tt.want {
+				t.Errorf("Nearest() = %s, want %s", city.Name, tt.want)
+			}
+			want, wantDistance := bruteForceNearest(t, tt.latitude, tt.longitude)
+			if city != want || math.Abs(distance-wantDistance) > 1e-6 {
+				t.Errorf("Nearest() = %s at %.3f km, brute force %s at %.3f km", city.Name, distance, want.Name, wantDistance)
+			}
+		})
+	}
+}
+
+func TestNearestMatchesBruteForce(t *testing.T) {
+	for latitude := -90.0; latitude <= 90; latitude += 7.5 {
+		for longitude := -180.0; longitude <= 180; longitude += 7.5 {
+			city, distance, err := Nearest(latitude, longitude)
+			if err != nil {
+				t.Fatalf("Nearest(%g, %g) error = %v", latitude, longitude, err)
+			}
+			want, wantDistance := bruteForceNearest(t, latitude, longitude)
+			// Ties between cities at the same distance may go either way.
+			if math.Abs(distance-wantDistance) > 1e-6 {
+				t.Errorf("Nearest(%g, %g) = %s at %.3f km, brute force %s at %.3f km",
+					latitude, longitude, city.Name, distance, want.Name, wantDistance)
+			}
+		}
+	}
+}
+
+func TestReverseGeocode(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		want      string
+		wantErr   string
+	}{
+		{"within a city", 48.86, 2.35, "Paris", ""},
+		{"away from cities", 47.5, 4.5, "Near ", ""},
+		{"latitude too large", 90.5, 0, "", "latitude"},
+		{"latitude too small", -91, 0, "", "latitude"},
+		{"longitude too large", 0, 180.5, "", "longitude"},
+		{"longitude too small", 0, -181, "", "longitude"},
+		{"NaN latitude", math.NaN(), 0, "", "latitude"},
+		{"NaN longitude", 0, math.NaN(), "", "longitude"},
+		{"infinite latitude", math.Inf(1), 0, "", "latitude"},
+		{"infinite longitude", 0, math.Inf(-1), "", "longitude"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ReverseGeocode(tt.latitude, tt.longitude)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReverseGeocode() error = %v, want a %s error", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReverseGeocode() error = %v", err)
+			}
+			if !strings.HasPrefix(result.Name, tt.want) {
+				t.Errorf("ReverseGeocode() name = %q, want prefix %q", result.Name, tt.want)
+			}
+			if result.Latitude != tt.latitude || result.Longitude != tt.longitude {
+				t.Errorf("ReverseGeocode() at %g, %g, want the given coordinates", result.Latitude, result.Longitude)
+			}
+			if result.ID != 0 {
+				t.Errorf("ReverseGeocode() ID = %d, want 0", result.ID)
+			}
+		})
+	}
+}
+
+func TestReverseGeocodeNearKeepsNoCityDetails(t *testing.T) {
+	result, err := ReverseGeocode(47.5, 4.5)
+	if err != nil {
+		t.Fatalf("ReverseGeocode() error = %v", err)
+	}
+	if result.Population != 0 || result.Timezone != "" || result.FeatureCode != "" {
+		t.Errorf("ReverseGeocode() kept the city population %d, timezone %q, feature code %q",
+			result.Population, result.Timezone, result.FeatureCode)
+	}
+}
+
+func TestKDTreeWithoutCities(t *testing.T) {
+	if _, ok := newKDTree(nil).nearest(0, 0); ok {
+		t.Error("nearest() on an empty tree reports a city")
+	}
+}
+
+func TestKDTreeNaN(t *testing.T) {
+	cities, err := Cities()
+	if err != nil {
+		t.Fatalf("Cities() error = %v", err)
+	}
+	if i, ok := newKDTree(cities).nearest(math.NaN(), 0); ok {
+		t.Errorf("nearest(NaN, 0) = %d, true, want false", i)
+	}
+}
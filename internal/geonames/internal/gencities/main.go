@@ -0,0 +1,233 @@
+// Command gencities generates cities.tsv, the city list bundled by the
+// geonames package, from the GeoNames dumps (https://www.geonames.org, CC BY
+// 4.0): every populated place of cities15000, with its first level
+// administrative area and country named. It runs in the package directory
+// through go generate.
+//
+// The dumps are downloaded unless -dir points at a directory holding
+// cities15000.zip (or cities15000.txt), admin1CodesASCII.txt and
+// countryInfo.txt.
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DUMP_URL     = "https://download.geonames.org/export/dump/"
+	CITIES_DUMP  = "cities15000"
+	ADMIN1_DUMP  = "admin1CodesASCII.txt"
+	COUNTRY_DUMP = "countryInfo.txt"
+	OUTPUT_FILE  = "cities.tsv"
+)
+
+// Feature codes of places that are no longer populated.
+var skippedFeatureCodes = []string{"PPLH", "PPLQ", "PPLW"}
+
+type city struct {
+	name        string
+	admin1      string
+	countryCode string
+	country     string
+	latitude    float64
+	longitude   float64
+	population  int
+	timezone    string
+}
+
+func main() {
+	dir := flag.String("dir", "", "Directory holding the GeoNames dumps, downloaded when empty")
+	flag.Parse()
+
+	if err := run(*dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate %s: %v\n", OUTPUT_FILE, err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string) error {
+	countries, err := readCountries(dir)
+	if err != nil {
+		return err
+	}
+	admin1, err := readAdmin1(dir)
+	if err != nil {
+		return err
+	}
+	cities, err := readCities(dir, countries, admin1)
+	if err != nil {
+		return err
+	}
+
+	// The largest cities first, as in a search without better ranking.
+	slices.SortStableFunc(cities, func(a, b city) int {
+		if a.population != b.population {
+			return b.population - a.population
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	var out bytes.Buffer
+	out.WriteString("name\tadmin1\tcountry_code\tcountry\tlatitude\tlongitude\tpopulation\ttimezone\n")
+	for _, c := range cities {
+		fmt.Fprintf(&out, "%s\t%s\t%s\t%s\t%.4f\t%.4f\t%d\t%s\n",
+			c.name, c.admin1, c.countryCode, c.country, c.latitude, c.longitude, c.population, c.timezone)
+	}
+	return os.WriteFile(OUTPUT_FILE, out.Bytes(), 0644)
+}
+
+// Country names by ISO code.
+func readCountries(dir string) (map[string]string, error) {
+	data, err := readDump(dir, COUNTRY_DUMP)
+	if err != nil {
+		return nil, err
+	}
+	countries := make(map[string]string)
+	err = eachRow(data, func(fields []string) error {
+		if len(fields) < 5 {
+			return fmt.Errorf("%s: expected at least 5 fields, got %d", COUNTRY_DUMP, len(fields))
+		}
+		countries[fields[0]] = fields[4]
+		return nil
+	})
+	return countries, err
+}
+
+// First level administrative area names by "CC.code".
+func readAdmin1(dir string) (map[string]string, error) {
+	data, err := readDump(dir, ADMIN1_DUMP)
+	if err != nil {
+		return nil, err
+	}
+	admin1 := make(map[string]string)
+	err = eachRow(data, func(fields []string) error {
+		if len(fields) < 2 {
+			return fmt.Errorf("%s: expected at least 2 fields, got %d", ADMIN1_DUMP, len(fields))
+		}
+		admin1[fields[0]] = fields[1]
+		return nil
+	})
+	return admin1, err
+}
+
+// The cities of the dump, see the GeoNames readme for its 19 columns.
+func readCities(dir string, countries map[string]string, admin1 map[string]string) ([]city, error) {
+	data, err := readDump(dir, CITIES_DUMP+".txt")
+	if err != nil {
+		return nil, err
+	}
+	var cities []city
+	err = eachRow(data, func(fields []string) error {
+		if len(fields) != 19 {
+			return fmt.Errorf("%s: expected 19 fields, got %d", CITIES_DUMP, len(fields))
+		}
+		if slices.Contains(skippedFeatureCodes, fields[7]) {
+			return nil
+		}
+		latitude, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return fmt.Errorf("%s: latitude of %s: %w", CITIES_DUMP, fields[1], err)
+		}
+		longitude, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return fmt.Errorf("%s: longitude of %s: %w", CITIES_DUMP, fields[1], err)
+		}
+		population, err := strconv.Atoi(fields[14])
+		if err != nil {
+			return fmt.Errorf("%s: population of %s: %w", CITIES_DUMP, fields[1], err)
+		}
+		countryCode := fields[8]
+		cities = append(cities, city{
+			name:        fields[1],
+			admin1:      admin1[countryCode+"."+fields[10]],
+			countryCode: countryCode,
+			country:     countries[countryCode],
+			latitude:    latitude,
+			longitude:   longitude,
+			population:  population,
+			timezone:    fields[17],
+		})
+		return nil
+	})
+	return cities, err
+}
+
+// Call fn with the tab separated fields of every row, skipping comments.
+func eachRow(data []byte, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := fn(strings.Split(text, "\t")); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Read a dump from dir, or download it when dir is empty. The cities come
+// zipped, they are read from the plain file when dir has it.
+func readDump(dir string, name string) ([]byte, error) {
+	zipped := name == CITIES_DUMP+".txt"
+	if dir != "" {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil || !zipped {
+			return data, err
+		}
+		data, err := os.ReadFile(filepath.Join(dir, CITIES_DUMP+".zip"))
+		if err != nil {
+			return nil, err
+		}
+		return unzip(data, name)
+	}
+
+	source := name
+	if zipped {
+		source = CITIES_DUMP + ".zip"
+	}
+	data, err := download(DUMP_URL + source)
+	if err != nil || !zipped {
+		return data, err
+	}
+	return unzip(data, name)
+}
+
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func unzip(data []byte, name string) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	file, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
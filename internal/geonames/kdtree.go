@@ -0,0 +1,111 @@
+package geonames
+
+import (
+	"math"
+	"slices"
+)
+
+const EARTH_RADIUS_KM = 6371.0
+
+// A k-d tree over the cities, stored as a flat slice: the median of every
+// range [lo, hi) sits at its middle, with the smaller half on its left.
+//
+// Coordinates are indexed as points on the unit sphere, so the straight line
+// distance between points orders them like the great-circle distance, with no
+// special cases at the poles or the antimeridian.
+type kdTree struct {
+	points []kdPoint
+}
+
+type kdPoint struct {
+	xyz  [3]float64
+	city int
+}
+
+func newKDTree(cities []City) kdTree {
+	points := make([]kdPoint, len(cities))
+	for i, city := range cities {
+		points[i] = kdPoint{xyz: toUnitVector(city.Latitude, city.Longitude), city: i}
+	}
+	build(points, 0)
+	return kdTree{points: points}
+}
+
+// Sort points so that every range has its median at the middle, splitting on
+// the x, y and z axes in turn.
+func build(points []kdPoint, depth int) {
+	if len(points) <= 1 {
+		return
+	}
+	axis := depth % 3
+	slices.SortFunc(points, func(a, b kdPoint) int {
+		switch {
+		case a.xyz[axis] < b.xyz[axis]:
+			return -1
+		case a.xyz[axis] > b.xyz[axis]:
+			return 1
+		}
+		return 0
+	})
+	mid := len(points) / 2
+	build(points[:mid], depth+1)
+	build(points[mid+1:], depth+1)
+}
+
+// Index of the city closest to the given coordinates. Reports false when there
+// is none, e.g. for NaN coordinates no city compares closer.
+func (t kdTree) nearest(latitude, longitude float64) (int, bool) {
+	target := toUnitVector(latitude, longitude)
+	best, bestDistance := -1, math.Inf(1)
+	t.search(t.points, 0, target, &best, &bestDistance)
+	return best, best >= 0
+}
+
+func (t kdTree) search(points []kdPoint, depth int, target [3]float64, best *int, bestDistance *float64) {
+	if len(points) == 0 {
+		return
+	}
+	mid := len(points) / 2
+	point := points[mid]
+	if distance := squaredDistance(point.xyz, target); distance < *bestDistance {
+		*best, *bestDistance = point.city, distance
+	}
+
+	axis := depth % 3
+	delta := target[axis] - point.xyz[axis]
+	near, far := points[:mid], points[mid+1:]
+	if delta > 0 {
+		near, far = far, near
+	}
+	t.search(near, depth+1, target, best, bestDistance)
+	// The far side can only hold a closer point if the splitting plane is.
+	if delta*delta < *bestDistance {
+		t.search(far, depth+1, target, best, bestDistance)
+	}
+}
+
+func toUnitVector(latitude, longitude float64) [3]float64 {
+	lat := latitude * math.Pi / 180
+	lon := longitude * math.Pi / 180
+	return [3]float64{
+		math.Cos(lat) * math.Cos(lon),
+		math.Cos(lat) * math.Sin(lon),
+		math.Sin(lat),
+	}
+}
+
+func squaredDistance(a, b [3]float64) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+// Great-circle distance with the haversine formula.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+	h := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EARTH_RADIUS_KM * math.Asin(math.Sqrt(min(1, h)))
+}
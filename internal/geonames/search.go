@@ -0,0 +1,64 @@
+package geonames
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// Search finds the cities matching name, for use when the geocoding API is
+// out of reach. Cities whose name starts with name come first, largest first,
+// followed by fuzzy matches by score. A non-empty countryCode limits the
+// results to that country. Returns at most count cities.
+func Search(name string, countryCode string, count int) ([]City, error) {
+	all, err := Cities()
+	if err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(strings.TrimSpace(name))
+	if query == "" || count <= 0 {
+		return nil, nil
+	}
+
+	var cities []City
+	for _, city := range all {
+		if countryCode == "" || strings.EqualFold(city.CountryCode, countryCode) {
+			cities = append(cities, city)
+		}
+	}
+
+	var prefixed []City
+	var others names
+	for _, city := range cities {
+		if strings.HasPrefix(strings.ToLower(city.Name), query) {
+			prefixed = append(prefixed, city)
+		} else {
+			others = append(others, city)
+		}
+	}
+	slices.SortStableFunc(prefixed, func(a, b City) int {
+		return cmp.Compare(b.Population, a.Population)
+	})
+
+	results := prefixed
+	for _, match := range fuzzy.FindFrom(query, others) {
+		results = append(results, others[match.Index])
+	}
+	if len(results) > count {
+		results = results[:count]
+	}
+	return results, nil
+}
+
+// Implements fuzzy.Source over the lowercased city names.
+type names []City
+
+func (n names) String(i int) string {
+	return strings.ToLower(n[i].Name)
+}
+
+func (n names) Len() int {
+	return len(n)
+}
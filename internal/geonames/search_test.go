@@ -0,0 +1,89 @@
+package geonames
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchPrefixesByPopulation(t *testing.T) {
+	cities, err := Search("San", "", 100)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(cities) == 0 || cities[0].Name != "Santiago" {
+		t.Fatalf("Search() first result = %v, want Santiago, the largest", cities)
+	}
+
+	// Prefix matches come first, largest first, then the fuzzy matches.
+	prefixed := true
+	for i, city := range cities {
+		hasPrefix := strings.HasPrefix(strings.ToLower(city.Name), "san")
+		if hasPrefix && !prefixed {
+			t.Errorf("prefix match %s after a fuzzy match", city.Name)
+		}
+		if !hasPrefix {
+			prefixed = false
+			continue
+		}
+		if i > 0 && city.Population > cities[i-1].Population {
+			t.Errorf("%s (%d) after the smaller %s (%d)", city.Name, city.Population, cities[i-1].Name, cities[i-1].Population)
+		}
+	}
+}
+
+func TestSearchCountry(t *testing.T) {
+	cities, err := Search("springfield", "us", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	want := []string{"Missouri", "Massachusetts", "Illinois"}
+	if len(cities) != len(want) {
+		t.Fatalf("Search() = %v, want the %d Springfields of the US", cities, len(want))
+	}
+	for i, city := range cities {
+		if city.Name != "Springfield" || city.CountryCode != "US" || city.Admin1 != want[i] {
+			t.Errorf("Search()[%d] = %s, %s, %s, want Springfield, %s, US", i, city.Name, city.Admin1, city.CountryCode, want[i])
+		}
+	}
+
+	if cities, _ := Search("springfield", "FR", 10); len(cities) != 0 {
+		t.Errorf("Search() in FR = %v, want none", cities)
+	}
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	cities, err := Search("sprngfld", "", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(cities) == 0 || cities[0].Name != "Springfield" {
+		t.Errorf("Search() = %v, want Springfield first", cities)
+	}
+}
+
+func TestSearchLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		count int
+		want  int
+	}{
+		{"count caps the results", "a", 5, 5},
+		{"zero count", "Paris", 0, 0},
+		{"negative count", "Paris", -1, 0},
+		{"empty query", "", 10, 0},
+		{"blank query", "   ", 10, 0},
+		{"no match", "zzzzzzzz", 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cities, err := Search(tt.query, "", tt.count)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if len(cities) != tt.want {
+				t.Errorf("Search() returned %d cities, want %d", len(cities), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Parameters for the Open-Meteo Air Quality V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/air-quality-api
+type AirQualityParams struct {
+	Latitude      float64
+	Longitude     float64
+	Timezone      string
+	ForecastHours int
+	ForecastDays  int
+	Current       []AirQualityVariables
+	Hourly        []AirQualityVariables
+}
+
+// Variables available to request from the Open-Meteo Air Quality V1 API.
+// The same names are used for current and hourly data. Pollen is only
+// available in Europe during the pollen season and is null elsewhere.
+type AirQualityVariables string
+
+// PollenVariables are the pollen species, in the order they are displayed.
+var PollenVariables = []AirQualityVariables{
+	AirQualityAlderPollen,
+	AirQualityBirchPollen,
+	AirQualityGrassPollen,
+	AirQualityMugwortPollen,
+	AirQualityOlivePollen,
+	AirQualityRagweedPollen,
+}
+
+// AirQualityResponse is a typed view of the Air Quality V1 payload, mapped
+// like ForecastResponse.
+type AirQualityResponse struct {
+	Latitude         float64
+	Longitude        float64
+	GenerationTimeMs float64
+	UTCOffsetSeconds int
+	Timezone         string
+	TimezoneAbbrev   string
+	Location         *time.Location
+	CurrentTime      time.Time
+	HourlyTimes      TimeAxis
+	Current          map[AirQualityVariables]FloatMeasurement
+	Hourly           map[AirQualityVariables]FloatSeries
+}
+
+// CurrentMeasurement retrieves a single current measurement if it was requested.
+func (a AirQualityResponse) CurrentMeasurement(variable AirQualityVariables) (FloatMeasurement, bool) {
+	if a.Current == nil {
+		return FloatMeasurement{}, false
+	}
+	m, ok := a.Current[variable]
+	return m, ok
+}
+
+// HourlySeries retrieves an hourly time series if it was requested.
+func (a AirQualityResponse) HourlySeries(variable AirQualityVariables) (FloatSeries, bool) {
+	if a.Hourly == nil {
+		return FloatSeries{}, false
+	}
+	series, ok := a.Hourly[variable]
+	return series, ok
+}
+
+const AIR_QUALITY_API_URL = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+// Retrieve the air quality and pollen forecast for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetAirQuality(params AirQualityParams) (AirQualityResponse, error) {
+	return DefaultClient.GetAirQuality(context.Background(), params)
+}
+
+// GetAirQuality retrieves the air quality for the given parameters. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetAirQuality(ctx context.Context, params AirQualityParams) (AirQualityResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.AirQualityURL, params.Latitude, params.Longitude)
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
+	}
+	if params.ForecastHours > 0 {
+		url += fmt.Sprintf("&forecast_hours=%d", params.ForecastHours)
+	}
+	if params.ForecastDays > 0 {
+		url += fmt.Sprintf("&forecast_days=%d", params.ForecastDays)
+	}
+	if len(params.Current) > 0 {
+		currentVars := writeVariableCSV(params.Current)
+		url += fmt.Sprintf("&current=%s", currentVars)
+	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
+
+	// The payload has the same layout as a forecast.
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return AirQualityResponse{}, err
+	}
+
+	return AirQualityResponse{
+		Latitude:         raw.Latitude,
+		Longitude:        raw.Longitude,
+		GenerationTimeMs: raw.GenerationTimeMs,
+		UTCOffsetSeconds: raw.UTCOffsetSeconds,
+		Timezone:         raw.Timezone,
+		TimezoneAbbrev:   raw.TimezoneAbbrev,
+		Location:         raw.location(),
+		CurrentTime:      raw.currentTime(),
+		HourlyTimes:      raw.hourlyTimes(),
+		Current:          toMeasurements(raw.Current, raw.CurrentUnits, airQualityVariableLookup),
+		Hourly:           toSeries(raw.Hourly, raw.HourlyUnits, airQualityVariableLookup),
+	}, nil
+}
+
+// MapEuropeanAQI returns the category label of a European AQI value.
+func MapEuropeanAQI(value float64) string {
+	switch {
+	case value < 20:
+		return "Good"
+	case value < 40:
+		return "Fair"
+	case value < 60:
+		return "Moderate"
+	case value < 80:
+		return "Poor"
+	case value < 100:
+		return "Very poor"
+	default:
+		return "Extremely poor"
+	}
+}
+
+// MapUSAQI returns the category label of a US AQI value.
+func MapUSAQI(value float64) string {
+	switch {
+	case value <= 50:
+		return "Good"
+	case value <= 100:
+		return "Moderate"
+	case value <= 150:
+		return "Unhealthy for sensitive groups"
+	case value <= 200:
+		return "Unhealthy"
+	case value <= 300:
+		return "Very unhealthy"
+	default:
+		return "Hazardous"
+	}
+}
@@ -0,0 +1,57 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Parameters for the Open-Meteo Historical Weather (archive) V1 API.
+// The dates are inclusive and only their calendar day is used.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/historical-weather-api
+type ArchiveParams struct {
+	Latitude  float64
+	Longitude float64
+	Timezone  string
+	StartDate time.Time
+	EndDate   time.Time
+	Daily     []DailyVariables
+	Hourly    []HourlyVariables
+	Units     Units
+}
+
+const ARCHIVE_API_URL = "https://archive-api.open-meteo.com/v1/archive"
+
+// Retrieve historical weather for a given location and date range.
+// Data is provided by the Open-Meteo API. The payload has the same shape as a
+// forecast, so it is returned as a ForecastResponse.
+func GetArchive(params ArchiveParams) (ForecastResponse, error) {
+	return DefaultClient.GetArchive(context.Background(), params)
+}
+
+// GetArchive retrieves historical weather for the given parameters. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetArchive(ctx context.Context, params ArchiveParams) (ForecastResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.ArchiveURL, params.Latitude, params.Longitude)
+	url += fmt.Sprintf("&start_date=%s&end_date=%s", params.StartDate.Format(time.DateOnly), params.EndDate.Format(time.DateOnly))
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
+	}
+	url += writeUnitsQuery(params.Units)
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
+
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return ForecastResponse{}, err
+	}
+
+	return raw.toForecastResponse(), nil
+}
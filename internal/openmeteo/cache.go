@@ -0,0 +1,197 @@
+package openmeteo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// How long cached responses are served without asking the API again. The
+// forecast models are updated every hour at best and the current conditions
+// every 15 minutes, places and terrain barely change.
+const (
+	FORECAST_CACHE_TTL  = 15 * time.Minute
+	GEOCODING_CACHE_TTL = 7 * 24 * time.Hour
+	ELEVATION_CACHE_TTL = 30 * 24 * time.Hour
+)
+
+// Responses stored on disk are deleted after this long without an update.
+const RESPONSE_CACHE_MAX_AGE = 30 * 24 * time.Hour
+
+// How many responses are kept in memory, the oldest make room for new ones.
+// Those on disk stay there.
+const RESPONSE_CACHE_MAX_ENTRIES = 256
+
+// ErrNotCached is returned for requests made with FromCache that have no
+// cached response.
+var ErrNotCached = errors.New("response not cached")
+
+// ResponseCache keeps the raw responses of the API in memory and, when it has
+// a directory, on disk so they outlive the program. It is safe for concurrent
+// use. Responses are keyed by request URL, with the query parameters sorted.
+type ResponseCache struct {
+	dir     string
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	Key       string          `json:"key"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// NewResponseCache returns a cache persisted in dir, or kept in memory only
+// when dir is empty. Responses older than RESPONSE_CACHE_MAX_AGE are removed
+// from dir.
+func NewResponseCache(dir string) *ResponseCache {
+	cache := &ResponseCache{dir: dir, entries: make(map[string]cacheEntry)}
+	if dir != "" {
+		cache.prune()
+	}
+	return cache
+}
+
+func (c *ResponseCache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		return entry, true
+	}
+	if c.dir == "" {
+		return cacheEntry{}, false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return cacheEntry{}, false
+	}
+	c.keep(entry)
+	return entry, true
+}
+
+// Store a response. Failing to write it to disk only costs a request later.
+func (c *ResponseCache) put(key string, body []byte, fetchedAt time.Time) {
+	entry := cacheEntry{Key: key, FetchedAt: fetchedAt, Body: body}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keep(entry)
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(key), data, 0644)
+}
+
+// Keep an entry in memory, evicting the oldest one when the cache is full.
+// c.mu must be held.
+func (c *ResponseCache) keep(entry cacheEntry) {
+	c.entries[entry.Key] = entry
+	if len(c.entries) <= RESPONSE_CACHE_MAX_ENTRIES {
+		return
+	}
+	var oldest cacheEntry
+	for _, e := range c.entries {
+		if oldest.Key == "" || e.FetchedAt.Before(oldest.FetchedAt) {
+			oldest = e
+		}
+	}
+	delete(c.entries, oldest.Key)
+}
+
+func (c *ResponseCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (c *ResponseCache) prune() {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		info, err := file.Info()
+		if err == nil && time.Since(info.ModTime()) > RESPONSE_CACHE_MAX_AGE {
+			_ = os.Remove(filepath.Join(c.dir, file.Name()))
+		}
+	}
+}
+
+// The same request always has the same key, whatever the order of its
+// query parameters.
+func cacheKey(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	parsed.RawQuery = parsed.Query().Encode()
+	return parsed.String()
+}
+
+type cacheMode int
+
+const (
+	cacheDefault cacheMode = iota
+	cacheOnly
+	cacheBypass
+)
+
+type cacheModeKey struct{}
+
+// FromCache makes the requests made with the returned context answer from the
+// cache whatever the age of the response, without reaching the API. Requests
+// without a cached response fail with ErrNotCached.
+func FromCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheOnly)
+}
+
+// BypassCache makes the requests made with the returned context reach the API
+// even when the cached response is fresh. The response is still cached.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheBypass)
+}
+
+// Like getJSON, answering from the client's cache when it holds a response
+// younger than ttl, see FromCache and BypassCache. Returns when the response
+// was fetched from the API.
+func (c *Client) getCachedJSON(ctx context.Context, url string, ttl time.Duration, target any) (time.Time, error) {
+	mode, _ := ctx.Value(cacheModeKey{}).(cacheMode)
+	key := cacheKey(url)
+	if c.Cache != nil && mode != cacheBypass {
+		if entry, ok := c.Cache.get(key); ok && (mode == cacheOnly || time.Since(entry.FetchedAt) < ttl) {
+			if err := json.Unmarshal(entry.Body, target); err == nil {
+				return entry.FetchedAt, nil
+			}
+		}
+	}
+	if mode == cacheOnly {
+		return time.Time{}, ErrNotCached
+	}
+
+	body, err := c.getBody(ctx, url)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return time.Time{}, err
+	}
+	fetchedAt := time.Now()
+	if c.Cache != nil {
+		c.Cache.put(key, body, fetchedAt)
+	}
+	return fetchedAt, nil
+}
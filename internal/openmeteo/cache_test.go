@@ -0,0 +1,77 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheEvictsOldestInMemory(t *testing.T) {
+	cache := NewResponseCache("")
+	start := time.Now()
+	for i := range RESPONSE_CACHE_MAX_ENTRIES + 1 {
+		cache.put(fmt.Sprintf("key%d", i), []byte("{}"), start.Add(time.Duration(i)*time.Second))
+	}
+
+	if len(cache.entries) != RESPONSE_CACHE_MAX_ENTRIES {
+		t.Errorf("cache holds %d entries, want %d", len(cache.entries), RESPONSE_CACHE_MAX_ENTRIES)
+	}
+	if _, ok := cache.get("key0"); ok {
+		t.Error("oldest entry still cached")
+	}
+	if _, ok := cache.get(fmt.Sprintf("key%d", RESPONSE_CACHE_MAX_ENTRIES)); !ok {
+		t.Error("newest entry not cached")
+	}
+}
+
+func TestResponseCacheKeepsEvictedEntriesOnDisk(t *testing.T) {
+	cache := NewResponseCache(t.TempDir())
+	start := time.Now()
+	for i := range RESPONSE_CACHE_MAX_ENTRIES + 1 {
+		cache.put(fmt.Sprintf("key%d", i), []byte("{}"), start.Add(time.Duration(i)*time.Second))
+	}
+
+	if _, ok := cache.get("key0"); !ok {
+		t.Error("evicted entry not read back from disk")
+	}
+	if len(cache.entries) != RESPONSE_CACHE_MAX_ENTRIES {
+		t.Errorf("cache holds %d entries, want %d", len(cache.entries), RESPONSE_CACHE_MAX_ENTRIES)
+	}
+}
+
+func TestGetCachedJSONModes(t *testing.T) {
+	var requests atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"value":1}`))
+	})
+	client.Cache = NewResponseCache("")
+
+	var target struct{ Value int }
+	get := func(ctx context.Context) error {
+		_, err := client.getCachedJSON(ctx, url+"/v1/test?b=2&a=1", time.Hour, &target)
+		return err
+	}
+
+	if err := get(FromCache(context.Background())); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("FromCache without a response error = %v, want ErrNotCached", err)
+	}
+	for range 2 {
+		if err := get(context.Background()); err != nil {
+			t.Fatalf("getCachedJSON() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("fresh response fetched %d times, want 1", got)
+	}
+	if err := get(BypassCache(context.Background())); err != nil {
+		t.Fatalf("BypassCache error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests after BypassCache, want 2", got)
+	}
+}
@@ -0,0 +1,157 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const DEFAULT_USER_AGENT = "clima (+https://github.com/diegoserranor/clima)"
+const DEFAULT_TIMEOUT = 15 * time.Second
+
+// Client talks to the Open-Meteo HTTP APIs. The zero value is not usable,
+// create one with NewClient and override the fields as needed, e.g. point the
+// base URLs at an httptest server.
+type Client struct {
+	ForecastURL   string
+	GeocodingURL  string
+	LocationURL   string
+	ArchiveURL    string
+	AirQualityURL string
+	MarineURL     string
+	EnsembleURL   string
+	ElevationURL  string
+	ClimateURL    string
+	FloodURL      string
+	HTTPClient    *http.Client
+	UserAgent     string
+	// Timeout bounds every request attempt made by the client. It is applied on
+	// top of the context passed by the caller, whichever expires first wins.
+	Timeout time.Duration
+	Retry   RetryPolicy
+	// Limiter throttles outgoing requests. Nil disables client side limiting.
+	Limiter *Limiter
+	// Cache keeps the forecast, geocoding and elevation responses. Nil
+	// disables caching.
+	Cache *ResponseCache
+}
+
+// NewClient returns a client configured with the public Open-Meteo endpoints.
+func NewClient() *Client {
+	return &Client{
+		ForecastURL:   FORECAST_API_URL,
+		GeocodingURL:  GEOCODING_API_URL,
+		LocationURL:   LOCATION_API_URL,
+		ArchiveURL:    ARCHIVE_API_URL,
+		AirQualityURL: AIR_QUALITY_API_URL,
+		MarineURL:     MARINE_API_URL,
+		EnsembleURL:   ENSEMBLE_API_URL,
+		ElevationURL:  ELEVATION_API_URL,
+		ClimateURL:    CLIMATE_API_URL,
+		FloodURL:      FLOOD_API_URL,
+		HTTPClient:    &http.Client{},
+		UserAgent:     DEFAULT_USER_AGENT,
+		Timeout:       DEFAULT_TIMEOUT,
+		Retry:         DefaultRetryPolicy,
+		Limiter:       NewLimiter(DEFAULT_LIMITER_INTERVAL, DEFAULT_LIMITER_BURST),
+	}
+}
+
+// DefaultClient backs the package-level functions.
+var DefaultClient = NewClient()
+
+// Send a GET request and decode the JSON response body into target.
+// Retryable failures are repeated according to the client's retry policy.
+func (c *Client) getJSON(ctx context.Context, url string, target any) error {
+	body, err := c.getBody(ctx, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, target)
+}
+
+// Send a GET request and read the response body, see getJSON.
+func (c *Client) getBody(ctx context.Context, url string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.doRequest(ctx, url)
+		if err == nil || attempt >= c.Retry.MaxAttempts || !IsRetryable(err) {
+			return body, err
+		}
+
+		delay, ok := c.Retry.backoff(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Make a single request attempt.
+func (c *Client) doRequest(ctx context.Context, url string) ([]byte, error) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	return body, nil
+}
+
+// Build an APIError from a failed response, keeping the reason if the body has one.
+func newAPIError(resp *http.Response, url string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		URL:        url,
+		RetryAfter: parseRetryAfter(resp.Header, time.Now()),
+	}
+	var raw apiErrorRaw
+	body := io.LimitReader(resp.Body, 64<<10)
+	if err := json.NewDecoder(body).Decode(&raw); err == nil {
+		apiErr.Reason = raw.Reason
+	}
+	return apiErr
+}
@@ -0,0 +1,201 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A client without limiter that retries quickly, pointed at handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient()
+	client.Limiter = nil
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return client, server.URL
+}
+
+func TestGetBodyDecodesAPIError(t *testing.T) {
+	var attempts atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":true,"reason":"Cannot initialize WeatherVariable from invalid String value foo"}`))
+	})
+
+	_, err := client.getBody(context.Background(), url)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got status %d, want 400", apiErr.StatusCode)
+	}
+	if apiErr.Reason != "Cannot initialize WeatherVariable from invalid String value foo" {
+		t.Errorf("got reason %q", apiErr.Reason)
+	}
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("got %v, want it to match ErrInvalidParams", err)
+	}
+	if IsRetryable(err) {
+		t.Errorf("invalid parameters should not be retryable")
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+}
+
+func TestGetBodyRetriesServerErrors(t *testing.T) {
+	var attempts atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+
+	body, err := client.getBody(context.Background(), url)
+	if err != nil {
+		t.Fatalf("got %v, want success after retries", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("got body %q", body)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("got %d attempts, want 3", n)
+	}
+}
+
+func TestGetBodyGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := client.getBody(context.Background(), url)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("got %v, want a 502 APIError", err)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("server errors should be retryable")
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("got %d attempts, want 3", n)
+	}
+}
+
+func TestGetBodyHonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	var first, second time.Time
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`{}`))
+	})
+	client.Retry.MaxDelay = 2 * time.Second
+
+	if _, err := client.getBody(context.Background(), url); err != nil {
+		t.Fatalf("got %v, want success after the requested wait", err)
+	}
+	if waited := second.Sub(first); waited < time.Second {
+		t.Errorf("retried after %v, want at least the 1s asked by Retry-After", waited)
+	}
+}
+
+func TestGetBodyFailsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	var attempts atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":true,"reason":"Daily API request limit exceeded"}`))
+	})
+
+	_, err := client.getBody(context.Background(), url)
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got %v, want it to match ErrRateLimited", err)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter != time.Hour {
+		t.Errorf("got RetryAfter %v, want 1h", apiErr.RetryAfter)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+}
+
+func TestGetBodyWrapsNetworkErrors(t *testing.T) {
+	// Nothing listens on the address once the server is closed.
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	client := NewClient()
+	client.Limiter = nil
+	client.Retry = RetryPolicy{}
+
+	_, err := client.getBody(context.Background(), url)
+
+	if !errors.Is(err, ErrNetwork) {
+		t.Fatalf("got %v, want it to match ErrNetwork", err)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("network failures should be retryable")
+	}
+}
+
+func TestGetBodyStopsOnCancel(t *testing.T) {
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.Retry.BaseDelay = time.Hour
+	client.Retry.MaxDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.getBody(ctx, url)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the context error", err)
+	}
+}
+
+func TestGetBodyWaitsForLimiter(t *testing.T) {
+	var attempts atomic.Int32
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Write([]byte(`{}`))
+	})
+	client.Limiter = NewLimiter(time.Hour, 1)
+
+	if _, err := client.getBody(context.Background(), url); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.getBody(ctx, url)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the second request to wait for a token", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("got %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,176 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Parameters for the Open-Meteo Climate V1 API, which serves downscaled
+// climate model runs from 1950 to 2050. The dates are inclusive and only
+// their calendar day is used.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/climate-api
+type ClimateParams struct {
+	Latitude  float64
+	Longitude float64
+	StartDate time.Time
+	EndDate   time.Time
+	// Model selects the climate model, e.g. "MRI_AGCM3_2_S" or "EC_Earth3P_HR".
+	Model string
+	Daily []DailyVariables
+	Units Units
+}
+
+const CLIMATE_API_URL = "https://climate-api.open-meteo.com/v1/climate"
+
+// The climate model used for the normals, bias corrected against ERA5 by the
+// API.
+const CLIMATE_NORMALS_MODEL = "MRI_AGCM3_2_S"
+
+// The 30-year reference period of the normals, as used by the WMO.
+var (
+	climateNormalsStart = time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC)
+	climateNormalsEnd   = time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// Days on each side of a date averaged into its normal, smoothing out the
+// noise of single days.
+const climateNormalsWindow = 7
+
+// Retrieve the modelled daily climate for a given location and date range.
+// Data is provided by the Open-Meteo API. The payload has the same shape as a
+// forecast, so it is returned as a ForecastResponse.
+func GetClimate(params ClimateParams) (ForecastResponse, error) {
+	return DefaultClient.GetClimate(context.Background(), params)
+}
+
+// GetClimate retrieves the modelled daily climate for the given parameters.
+// The request is aborted when ctx is cancelled.
+func (c *Client) GetClimate(ctx context.Context, params ClimateParams) (ForecastResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.ClimateURL, params.Latitude, params.Longitude)
+	url += fmt.Sprintf("&start_date=%s&end_date=%s", params.StartDate.Format(time.DateOnly), params.EndDate.Format(time.DateOnly))
+	if params.Model != "" {
+		url += fmt.Sprintf("&models=%s", params.Model)
+	}
+	url += writeUnitsQuery(params.Units)
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return ForecastResponse{}, err
+	}
+
+	// The variables may carry the model as suffix, even for a single model.
+	if params.Model != "" {
+		for key := range raw.DailyUnits {
+			if strings.HasSuffix(key, "_"+params.Model) {
+				raw = raw.forModel(WeatherModel(params.Model))
+				break
+			}
+		}
+	}
+	return raw.toForecastResponse(), nil
+}
+
+// ClimateNormal holds the average daily temperatures of a calendar day over
+// the reference period, in degrees Celsius.
+type ClimateNormal struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// ClimateNormals holds the normal of every calendar day, February 29 included.
+type ClimateNormals struct {
+	Model string `json:"model"`
+	// Period is the reference period, e.g. "1991-2020".
+	Period string          `json:"period"`
+	Days   []ClimateNormal `json:"days"`
+}
+
+// At returns the normal of the calendar day of t, false when unknown.
+func (n ClimateNormals) At(t time.Time) (ClimateNormal, bool) {
+	i := dayOfYearIndex(t)
+	if i >= len(n.Days) {
+		return ClimateNormal{}, false
+	}
+	return n.Days[i], true
+}
+
+// The index of a calendar day in a leap year, so that every day has one.
+func dayOfYearIndex(t time.Time) int {
+	return time.Date(2000, t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).YearDay() - 1
+}
+
+// Gets the 1991-2020 temperature normals of a location, see ClimateNormals.
+// Data is provided by the Open-Meteo API.
+func GetClimateNormals(latitude, longitude float64) (ClimateNormals, error) {
+	return DefaultClient.GetClimateNormals(context.Background(), latitude, longitude)
+}
+
+// GetClimateNormals computes the 1991-2020 temperature normals of a location
+// from the daily climate of CLIMATE_NORMALS_MODEL. The normal of a day averages
+// the days within climateNormalsWindow of it over every year. The request is
+// aborted when ctx is cancelled.
+func (c *Client) GetClimateNormals(ctx context.Context, latitude, longitude float64) (ClimateNormals, error) {
+	climate, err := c.GetClimate(ctx, ClimateParams{
+		Latitude:  latitude,
+		Longitude: longitude,
+		StartDate: climateNormalsStart,
+		EndDate:   climateNormalsEnd,
+		Model:     CLIMATE_NORMALS_MODEL,
+		Daily: []DailyVariables{
+			DailyTemperature2mMin,
+			DailyTemperature2mMax,
+			DailyTemperature2mMean,
+		},
+		Units: Units{Temperature: Celsius},
+	})
+	if err != nil {
+		return ClimateNormals{}, err
+	}
+
+	minSeries, _ := climate.DailySeries(DailyTemperature2mMin)
+	maxSeries, _ := climate.DailySeries(DailyTemperature2mMax)
+	meanSeries, _ := climate.DailySeries(DailyTemperature2mMean)
+
+	const days = 366
+	var sums, counts [days][3]float64
+	for i, t := range climate.DailyTimes {
+		center := dayOfYearIndex(t)
+		for s, series := range []FloatSeries{minSeries, maxSeries, meanSeries} {
+			value, ok := series.At(i)
+			if !ok {
+				continue
+			}
+			for offset := -climateNormalsWindow; offset <= climateNormalsWindow; offset++ {
+				day := (center + offset + days) % days
+				sums[day][s] += value
+				counts[day][s]++
+			}
+		}
+	}
+
+	normals := ClimateNormals{
+		Model:  CLIMATE_NORMALS_MODEL,
+		Period: fmt.Sprintf("%d-%d", climateNormalsStart.Year(), climateNormalsEnd.Year()),
+		Days:   make([]ClimateNormal, days),
+	}
+	for day := range days {
+		var values [3]float64
+		for s := range values {
+			if counts[day][s] == 0 {
+				return ClimateNormals{}, fmt.Errorf("open-meteo returned no climate data around day %d of the year", day+1)
+			}
+			values[s] = math.Round(sums[day][s]/counts[day][s]*10) / 10
+		}
+		normals.Days[day] = ClimateNormal{Min: values[0], Max: values[1], Mean: values[2]}
+	}
+	return normals, nil
+}
@@ -0,0 +1,121 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Answers a daily request for every day between its start_date and end_date,
+// the value of each variable given by its function of the day.
+func serveDaily(t *testing.T, w http.ResponseWriter, r *http.Request, unit string, variables map[string]func(time.Time) any) {
+	t.Helper()
+	start, err := time.Parse(time.DateOnly, r.URL.Query().Get("start_date"))
+	if err != nil {
+		t.Fatalf("start_date: %v", err)
+	}
+	end, err := time.Parse(time.DateOnly, r.URL.Query().Get("end_date"))
+	if err != nil {
+		t.Fatalf("end_date: %v", err)
+	}
+
+	daily := map[string][]any{}
+	units := map[string]string{"time": "iso8601"}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		daily["time"] = append(daily["time"], day.Format(time.DateOnly))
+		for name, value := range variables {
+			daily[name] = append(daily[name], value(day))
+		}
+	}
+	for name := range variables {
+		units[name] = unit
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"latitude":    46,
+		"longitude":   7,
+		"timezone":    "GMT",
+		"daily_units": units,
+		"daily":       daily,
+	})
+}
+
+func TestGetClimateNormals(t *testing.T) {
+	var query string
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		serveDaily(t, w, r, "°C", map[string]func(time.Time) any{
+			// The day of the year, so the window shows in the normal.
+			"temperature_2m_mean": func(day time.Time) any { return float64(dayOfYearIndex(day)) },
+			// The same for every day of a year, so every year counts alike.
+			"temperature_2m_min": func(day time.Time) any { return float64(day.Year() - 1991) },
+			// Only February 29 stands out, and only in leap years.
+			"temperature_2m_max": func(day time.Time) any {
+				if day.Month() == time.February && day.Day() == 29 {
+					return 100.0
+				}
+				return 25.0
+			},
+		})
+	})
+	client.ClimateURL = url
+
+	normals, err := client.GetClimateNormals(context.Background(), 46, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "latitude=46.000000&longitude=7.000000&start_date=1991-01-01&end_date=2020-12-31" +
+		"&models=MRI_AGCM3_2_S&temperature_unit=celsius" +
+		"&daily=temperature_2m_min,temperature_2m_max,temperature_2m_mean"
+	if query != want {
+		t.Errorf("got query\n%s\nwant\n%s", query, want)
+	}
+	if normals.Period != "1991-2020" || len(normals.Days) != 366 {
+		t.Fatalf("got period %q with %d days, want 1991-2020 with 366", normals.Period, len(normals.Days))
+	}
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want ClimateNormal
+	}{
+		// Days 93 to 107 of the year, around April 10.
+		{"spring", time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC), ClimateNormal{Min: 14.5, Max: 25, Mean: 100}},
+		// The window wraps around to the end of the previous year:
+		// (359 + ... + 365 + 0 + ... + 7) / 15.
+		{"new year", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), ClimateNormal{Min: 14.5, Max: 25, Mean: 170.8}},
+		// February 29 of the 8 leap years among the 428 days pooled:
+		// (420 * 25 + 8 * 100) / 428.
+		{"leap day", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), ClimateNormal{Min: 14.5, Max: 26.4, Mean: 59}},
+		// Day 59 weighs less than the others in the window of day 60.
+		{"after the leap day", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), ClimateNormal{Min: 14.5, Max: 26.4, Mean: 60.1}},
+		{"out of reach of the leap day", time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), ClimateNormal{Min: 14.5, Max: 25, Mean: 74}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := normals.At(tt.day)
+			if !ok {
+				t.Fatalf("At(%s) reports no normal", tt.day.Format(time.DateOnly))
+			}
+			if got != tt.want {
+				t.Errorf("At(%s) = %+v, want %+v", tt.day.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetClimateNormalsFailsWithoutData(t *testing.T) {
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		serveDaily(t, w, r, "°C", map[string]func(time.Time) any{
+			"temperature_2m_mean": func(time.Time) any { return nil },
+			"temperature_2m_min":  func(time.Time) any { return nil },
+			"temperature_2m_max":  func(time.Time) any { return nil },
+		})
+	})
+	client.ClimateURL = url
+
+	if _, err := client.GetClimateNormals(context.Background(), 46, 7); err == nil {
+		t.Error("GetClimateNormals() without data returned no error")
+	}
+}
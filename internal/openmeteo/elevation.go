@@ -0,0 +1,46 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Response from the Open-Meteo Elevation V1 API, in meters above sea level,
+// in the order of the requested coordinates.
+type elevationResponseRaw struct {
+	Elevation []float64 `json:"elevation"`
+}
+
+const ELEVATION_API_URL = "https://api.open-meteo.com/v1/elevation"
+
+// Gets the terrain elevation in meters of every location, from a 90 meter
+// digital elevation model. Data is provided by the Open-Meteo API.
+func GetElevation(locations []Coordinates) ([]float64, error) {
+	return DefaultClient.GetElevation(context.Background(), locations)
+}
+
+// GetElevation gets the terrain elevation in meters of every location, in the
+// order of locations. The request is aborted when ctx is cancelled.
+func (c *Client) GetElevation(ctx context.Context, locations []Coordinates) ([]float64, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	latitudes := make([]string, len(locations))
+	longitudes := make([]string, len(locations))
+	for i, location := range locations {
+		latitudes[i] = fmt.Sprintf("%f", location.Latitude)
+		longitudes[i] = fmt.Sprintf("%f", location.Longitude)
+	}
+	url := fmt.Sprintf("%s?latitude=%s&longitude=%s", c.ElevationURL, strings.Join(latitudes, ","), strings.Join(longitudes, ","))
+
+	var raw elevationResponseRaw
+	if _, err := c.getCachedJSON(ctx, url, ELEVATION_CACHE_TTL, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw.Elevation) != len(locations) {
+		return nil, fmt.Errorf("open-meteo returned %d elevations for %d locations", len(raw.Elevation), len(locations))
+	}
+	return raw.Elevation, nil
+}
@@ -0,0 +1,216 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Parameters for the Open-Meteo Ensemble V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/ensemble-api
+type EnsembleParams struct {
+	Latitude     float64
+	Longitude    float64
+	Timezone     string
+	ForecastDays int
+	// Models selects the ensemble models, e.g. ICONEnsemble or ECMWFEnsemble.
+	// The members of every model are pooled together.
+	Models []EnsembleModel
+	Daily  []DailyVariables
+	Hourly []HourlyVariables
+	Units  Units
+}
+
+// Ensemble models accepted by the models parameter of the Ensemble V1 API.
+type EnsembleModel string
+
+const (
+	ECMWFEnsemble EnsembleModel = "ecmwf_ifs025"
+	GFSEnsemble   EnsembleModel = "gfs_seamless"
+	ICONEnsemble  EnsembleModel = "icon_seamless"
+	GEMEnsemble   EnsembleModel = "gem_global"
+)
+
+// EnsembleSeries summarizes the members of an ensemble at every time step.
+// Steps where no member has data hold NaN.
+type EnsembleSeries struct {
+	Min     FloatSeries
+	Median  FloatSeries
+	Max     FloatSeries
+	Members int
+}
+
+// EnsembleResponse is a typed view of the Ensemble V1 payload. Every variable
+// comes back once per member, these are aggregated into an EnsembleSeries.
+type EnsembleResponse struct {
+	Latitude         float64
+	Longitude        float64
+	Elevation        float64
+	GenerationTimeMs float64
+	UTCOffsetSeconds int
+	Timezone         string
+	TimezoneAbbrev   string
+	Location         *time.Location
+	HourlyTimes      TimeAxis
+	DailyTimes       TimeAxis
+	Daily            map[DailyVariables]EnsembleSeries
+	Hourly           map[HourlyVariables]EnsembleSeries
+}
+
+// DailySeries retrieves the spread of a daily variable if it was requested.
+func (e EnsembleResponse) DailySeries(variable DailyVariables) (EnsembleSeries, bool) {
+	if e.Daily == nil {
+		return EnsembleSeries{}, false
+	}
+	series, ok := e.Daily[variable]
+	return series, ok
+}
+
+// HourlySeries retrieves the spread of an hourly variable if it was requested.
+func (e EnsembleResponse) HourlySeries(variable HourlyVariables) (EnsembleSeries, bool) {
+	if e.Hourly == nil {
+		return EnsembleSeries{}, false
+	}
+	series, ok := e.Hourly[variable]
+	return series, ok
+}
+
+const ENSEMBLE_API_URL = "https://ensemble-api.open-meteo.com/v1/ensemble"
+
+// Retrieve the ensemble forecast for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetEnsemble(params EnsembleParams) (EnsembleResponse, error) {
+	return DefaultClient.GetEnsemble(context.Background(), params)
+}
+
+// GetEnsemble retrieves the ensemble forecast for the given parameters. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetEnsemble(ctx context.Context, params EnsembleParams) (EnsembleResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.EnsembleURL, params.Latitude, params.Longitude)
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
+	}
+	if params.ForecastDays > 0 {
+		url += fmt.Sprintf("&forecast_days=%d", params.ForecastDays)
+	}
+	if len(params.Models) > 0 {
+		url += fmt.Sprintf("&models=%s", writeVariableCSV(params.Models))
+	}
+	url += writeUnitsQuery(params.Units)
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
+
+	// The payload has the same layout as a forecast, with one key per member.
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return EnsembleResponse{}, err
+	}
+
+	return EnsembleResponse{
+		Latitude:         raw.Latitude,
+		Longitude:        raw.Longitude,
+		Elevation:        raw.Elevation,
+		GenerationTimeMs: raw.GenerationTimeMs,
+		UTCOffsetSeconds: raw.UTCOffsetSeconds,
+		Timezone:         raw.Timezone,
+		TimezoneAbbrev:   raw.TimezoneAbbrev,
+		Location:         raw.location(),
+		HourlyTimes:      raw.hourlyTimes(),
+		DailyTimes:       raw.dailyTimes(),
+		Daily:            toEnsembleSeries(raw.Daily, raw.DailyUnits, dailyVariableLookup, params.Models),
+		Hourly:           toEnsembleSeries(raw.Hourly, raw.HourlyUnits, hourlyVariableLookup, params.Models),
+	}, nil
+}
+
+// Group the member series of an "hourly" or "daily" object by variable and
+// aggregate them. Keys look like "temperature_2m" for the control run and
+// "temperature_2m_member01" for the others, followed by "_<model>" when
+// several models are requested.
+func toEnsembleSeries[T ~string](values map[string][]any, units map[string]string, lookup map[string]T, models []EnsembleModel) map[T]EnsembleSeries {
+	result := make(map[T]EnsembleSeries)
+	if values == nil || units == nil {
+		return result
+	}
+
+	members := make(map[T][][]float64)
+	memberUnits := make(map[T]string)
+	for key, unit := range units {
+		name := key
+		if len(models) > 1 {
+			for _, model := range models {
+				if trimmed, ok := strings.CutSuffix(name, "_"+string(model)); ok {
+					name = trimmed
+					break
+				}
+			}
+		}
+		if i := strings.LastIndex(name, "_member"); i >= 0 {
+			name = name[:i]
+		}
+		variable, ok := lookup[name]
+		if !ok {
+			continue
+		}
+		floats, ok := toFloatSlice(values[key])
+		if !ok {
+			continue
+		}
+		members[variable] = append(members[variable], floats)
+		memberUnits[variable] = unit
+	}
+
+	for variable, series := range members {
+		result[variable] = aggregateMembers(series, memberUnits[variable])
+	}
+	return result
+}
+
+// Compute the min, median and max of the members at every step, ignoring
+// the members without data.
+func aggregateMembers(members [][]float64, unit string) EnsembleSeries {
+	steps := 0
+	for _, member := range members {
+		steps = max(steps, len(member))
+	}
+
+	aggregate := EnsembleSeries{
+		Min:     FloatSeries{Values: make([]float64, steps), Unit: unit},
+		Median:  FloatSeries{Values: make([]float64, steps), Unit: unit},
+		Max:     FloatSeries{Values: make([]float64, steps), Unit: unit},
+		Members: len(members),
+	}
+	values := make([]float64, 0, len(members))
+	for step := range steps {
+		values = values[:0]
+		for _, member := range members {
+			if step < len(member) && !math.IsNaN(member[step]) {
+				values = append(values, member[step])
+			}
+		}
+		if len(values) == 0 {
+			aggregate.Min.Values[step] = math.NaN()
+			aggregate.Median.Values[step] = math.NaN()
+			aggregate.Max.Values[step] = math.NaN()
+			continue
+		}
+		slices.Sort(values)
+		median := values[len(values)/2]
+		if len(values)%2 == 0 {
+			median = (values[len(values)/2-1] + median) / 2
+		}
+		aggregate.Min.Values[step] = values[0]
+		aggregate.Median.Values[step] = median
+		aggregate.Max.Values[step] = values[len(values)-1]
+	}
+	return aggregate
+}
@@ -0,0 +1,70 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrRateLimited is matched by API errors with status 429.
+	ErrRateLimited = errors.New("rate limited by Open-Meteo")
+	// ErrInvalidParams is matched by API errors with status 400.
+	ErrInvalidParams = errors.New("invalid request parameters")
+	// ErrNetwork wraps failures to reach the API at all, e.g. DNS or connection errors.
+	ErrNetwork = errors.New("network failure")
+)
+
+// APIError is returned when Open-Meteo answers with a non-200 status. The API
+// reports the cause as {"error": true, "reason": "..."} which is kept in Reason.
+type APIError struct {
+	StatusCode int
+	Reason     string
+	URL        string
+	// RetryAfter is the wait requested by the API, zero when not specified.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("open-meteo request failed with status %d", e.StatusCode)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Unwrap maps the status code onto the sentinel errors so callers can use errors.Is.
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest:
+		return ErrInvalidParams
+	default:
+		return nil
+	}
+}
+
+// IsRetryable reports whether repeating the request that produced err could succeed.
+// Network failures, rate limiting and server side errors are retryable, invalid
+// parameters and cancellations are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrNetwork) || errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+type apiErrorRaw struct {
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
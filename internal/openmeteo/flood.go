@@ -0,0 +1,265 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// Parameters for the Open-Meteo Flood V1 API, which serves the river
+// discharge of the GloFAS model on a 5 km grid, from 1984 up to 7 months
+// ahead. The dates are inclusive and only their calendar day is used, zero
+// values are left out of the request.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/flood-api
+type FloodParams struct {
+	Latitude     float64
+	Longitude    float64
+	Timezone     string
+	PastDays     int
+	ForecastDays int
+	StartDate    time.Time
+	EndDate      time.Time
+	Daily        []FloodVariables
+}
+
+// Daily variables available to request from the Open-Meteo Flood V1 API. All
+// but FloodRiverDischarge summarize the members of the GloFAS ensemble.
+type FloodVariables string
+
+// FloodResponse is a typed view of the Flood V1 payload, mapped like
+// ForecastResponse.
+type FloodResponse struct {
+	Latitude         float64
+	Longitude        float64
+	GenerationTimeMs float64
+	UTCOffsetSeconds int
+	Timezone         string
+	TimezoneAbbrev   string
+	Location         *time.Location
+	DailyTimes       TimeAxis
+	Daily            map[FloodVariables]FloatSeries
+}
+
+// DailySeries retrieves a daily time series if it was requested.
+func (f FloodResponse) DailySeries(variable FloodVariables) (FloatSeries, bool) {
+	if f.Daily == nil {
+		return FloatSeries{}, false
+	}
+	series, ok := f.Daily[variable]
+	return series, ok
+}
+
+// HasData reports whether the response holds any value. Away from rivers, and
+// over the sea, every value is null.
+func (f FloodResponse) HasData() bool {
+	for _, series := range f.Daily {
+		for _, value := range series.Values {
+			if !math.IsNaN(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const FLOOD_API_URL = "https://flood-api.open-meteo.com/v1/flood"
+
+// Retrieve the river discharge for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetFlood(params FloodParams) (FloodResponse, error) {
+	return DefaultClient.GetFlood(context.Background(), params)
+}
+
+// GetFlood retrieves the river discharge for the given parameters. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetFlood(ctx context.Context, params FloodParams) (FloodResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.FloodURL, params.Latitude, params.Longitude)
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
+	}
+	if params.PastDays > 0 {
+		url += fmt.Sprintf("&past_days=%d", params.PastDays)
+	}
+	if params.ForecastDays > 0 {
+		url += fmt.Sprintf("&forecast_days=%d", params.ForecastDays)
+	}
+	if !params.StartDate.IsZero() {
+		url += fmt.Sprintf("&start_date=%s", params.StartDate.Format(time.DateOnly))
+	}
+	if !params.EndDate.IsZero() {
+		url += fmt.Sprintf("&end_date=%s", params.EndDate.Format(time.DateOnly))
+	}
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+
+	// The payload has the same layout as a forecast.
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return FloodResponse{}, err
+	}
+
+	return FloodResponse{
+		Latitude:         raw.Latitude,
+		Longitude:        raw.Longitude,
+		GenerationTimeMs: raw.GenerationTimeMs,
+		UTCOffsetSeconds: raw.UTCOffsetSeconds,
+		Timezone:         raw.Timezone,
+		TimezoneAbbrev:   raw.TimezoneAbbrev,
+		Location:         raw.location(),
+		DailyTimes:       raw.dailyTimes(),
+		Daily:            toSeries(raw.Daily, raw.DailyUnits, floodVariableLookup),
+	}, nil
+}
+
+// DischargeBand holds the usual range of the river discharge on every
+// calendar day, February 29 included: the 10th and 90th percentiles of the
+// discharge over the reference period. Days without data hold NaN.
+type DischargeBand struct {
+	// Period is the reference period, e.g. "2003-2022".
+	Period string
+	Unit   string
+	Low    []float64
+	High   []float64
+}
+
+// The JSON form of a DischargeBand, days without data are null.
+type dischargeBandJSON struct {
+	Period string     `json:"period"`
+	Unit   string     `json:"unit"`
+	Low    []*float64 `json:"low"`
+	High   []*float64 `json:"high"`
+}
+
+func (b DischargeBand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dischargeBandJSON{
+		Period: b.Period,
+		Unit:   b.Unit,
+		Low:    toNullable(b.Low),
+		High:   toNullable(b.High),
+	})
+}
+
+func (b *DischargeBand) UnmarshalJSON(data []byte) error {
+	var raw dischargeBandJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*b = DischargeBand{
+		Period: raw.Period,
+		Unit:   raw.Unit,
+		Low:    fromNullable(raw.Low),
+		High:   fromNullable(raw.High),
+	}
+	return nil
+}
+
+// NaN values become nil.
+func toNullable(values []float64) []*float64 {
+	nullable := make([]*float64, len(values))
+	for i, value := range values {
+		if !math.IsNaN(value) {
+			nullable[i] = &value
+		}
+	}
+	return nullable
+}
+
+// Nil values become NaN.
+func fromNullable(nullable []*float64) []float64 {
+	values := make([]float64, len(nullable))
+	for i, value := range nullable {
+		values[i] = math.NaN()
+		if value != nil {
+			values[i] = *value
+		}
+	}
+	return values
+}
+
+// At returns the band of the calendar day of t, false when unknown.
+func (b DischargeBand) At(t time.Time) (float64, float64, bool) {
+	i := dayOfYearIndex(t)
+	if i >= len(b.Low) || i >= len(b.High) || math.IsNaN(b.Low[i]) || math.IsNaN(b.High[i]) {
+		return 0, 0, false
+	}
+	return b.Low[i], b.High[i], true
+}
+
+// The reference period of the discharge band, the last 20 complete years of
+// the consolidated GloFAS reanalysis.
+var (
+	dischargeBandStart = time.Date(2003, time.January, 1, 0, 0, 0, 0, time.UTC)
+	dischargeBandEnd   = time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// Days on each side of a date pooled into its band, see climateNormalsWindow.
+const dischargeBandWindow = 7
+
+// Gets the usual range of the river discharge of a location, see DischargeBand.
+// Data is provided by the Open-Meteo API.
+func GetDischargeBand(latitude, longitude float64) (DischargeBand, error) {
+	return DefaultClient.GetDischargeBand(context.Background(), latitude, longitude)
+}
+
+// GetDischargeBand computes the usual range of the river discharge of a
+// location from its 2003-2022 daily discharge. The band of a day pools the
+// days within dischargeBandWindow of it over every year. The request is
+// aborted when ctx is cancelled.
+func (c *Client) GetDischargeBand(ctx context.Context, latitude, longitude float64) (DischargeBand, error) {
+	history, err := c.GetFlood(ctx, FloodParams{
+		Latitude:  latitude,
+		Longitude: longitude,
+		StartDate: dischargeBandStart,
+		EndDate:   dischargeBandEnd,
+		Daily:     []FloodVariables{FloodRiverDischarge},
+	})
+	if err != nil {
+		return DischargeBand{}, err
+	}
+	discharge, _ := history.DailySeries(FloodRiverDischarge)
+
+	const days = 366
+	pooled := make([][]float64, days)
+	for i, t := range history.DailyTimes {
+		value, ok := discharge.At(i)
+		if !ok {
+			continue
+		}
+		center := dayOfYearIndex(t)
+		for offset := -dischargeBandWindow; offset <= dischargeBandWindow; offset++ {
+			day := (center + offset + days) % days
+			pooled[day] = append(pooled[day], value)
+		}
+	}
+
+	band := DischargeBand{
+		Period: fmt.Sprintf("%d-%d", dischargeBandStart.Year(), dischargeBandEnd.Year()),
+		Unit:   discharge.Unit,
+		Low:    make([]float64, days),
+		High:   make([]float64, days),
+	}
+	for day, values := range pooled {
+		if len(values) == 0 {
+			band.Low[day], band.High[day] = math.NaN(), math.NaN()
+			continue
+		}
+		slices.Sort(values)
+		band.Low[day] = percentile(values, 0.1)
+		band.High[day] = percentile(values, 0.9)
+	}
+	return band, nil
+}
+
+// The p-th quantile of sorted values, interpolating between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := min(lower+1, len(sorted)-1)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
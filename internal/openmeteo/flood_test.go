@@ -0,0 +1,137 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDischargeBandJSONKeepsDaysWithoutData(t *testing.T) {
+	band := DischargeBand{
+		Period: "2003-2022",
+		Unit:   "m³/s",
+		Low:    []float64{12.5, math.NaN()},
+		High:   []float64{40, math.NaN()},
+	}
+
+	data, err := json.Marshal(band)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got DischargeBand
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.Period != band.Period || got.Unit != band.Unit {
+		t.Errorf("got period %q, unit %q, want %q, %q", got.Period, got.Unit, band.Period, band.Unit)
+	}
+	low, high, ok := got.At(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	if !ok || low != 12.5 || high != 40 {
+		t.Errorf("At(Jan 1) = %v, %v, %v, want 12.5, 40, true", low, high, ok)
+	}
+	if _, _, ok := got.At(time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("At(Jan 2) reports a band for a day without data")
+	}
+}
+
+func TestGetDischargeBand(t *testing.T) {
+	var query string
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		serveDaily(t, w, r, "m³/s", map[string]func(time.Time) any{
+			// The day of the year, the same every year.
+			"river_discharge": func(day time.Time) any { return float64(dayOfYearIndex(day)) },
+		})
+	})
+	client.FloodURL = url
+
+	band, err := client.GetDischargeBand(context.Background(), 46, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "latitude=46.000000&longitude=7.000000&start_date=2003-01-01&end_date=2022-12-31&daily=river_discharge"
+	if query != want {
+		t.Errorf("got query\n%s\nwant\n%s", query, want)
+	}
+	if band.Period != "2003-2022" || band.Unit != "m³/s" {
+		t.Errorf("got period %q, unit %q, want 2003-2022, m³/s", band.Period, band.Unit)
+	}
+
+	tests := []struct {
+		name      string
+		day       time.Time
+		low, high float64
+	}{
+		// Days 93 to 107 of 20 years: the 10th percentile falls among the
+		// second day, the 90th among the second to last.
+		{"spring", time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC), 94, 106},
+		// The window of January 1 reaches back to December 25.
+		{"new year", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), 1, 364},
+		// The window of December 31 reaches on to January 7.
+		{"new year's eve", time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC), 1, 364},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high, ok := band.At(tt.day)
+			if !ok {
+				t.Fatalf("At(%s) reports no band", tt.day.Format(time.DateOnly))
+			}
+			if low != tt.low || high != tt.high {
+				t.Errorf("At(%s) = %v, %v, want %v, %v", tt.day.Format(time.DateOnly), low, high, tt.low, tt.high)
+			}
+		})
+	}
+}
+
+func TestGetDischargeBandWithoutData(t *testing.T) {
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		serveDaily(t, w, r, "m³/s", map[string]func(time.Time) any{
+			// Only the first half of the year has data.
+			"river_discharge": func(day time.Time) any {
+				if day.Month() > time.June {
+					return nil
+				}
+				return 10.0
+			},
+		})
+	})
+	client.FloodURL = url
+
+	band, err := client.GetDischargeBand(context.Background(), 46, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if low, high, ok := band.At(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)); !ok || low != 10 || high != 10 {
+		t.Errorf("At(March 1) = %v, %v, %v, want 10, 10, true", low, high, ok)
+	}
+	if _, _, ok := band.At(time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("At(September 1) reports a band for a day without data")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"single value", []float64{7}, 0.9, 7},
+		{"minimum", []float64{1, 2, 3, 4}, 0, 1},
+		{"maximum", []float64{1, 2, 3, 4}, 1, 4},
+		{"median", []float64{1, 2, 3, 4}, 0.5, 2.5},
+		{"interpolated", []float64{1, 2, 3, 4}, 0.1, 1.3},
+		{"interpolated near the top", []float64{10, 20, 30, 40, 50}, 0.9, 46},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
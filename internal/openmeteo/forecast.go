@@ -1,24 +1,48 @@
 package openmeteo
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"math"
 	"strings"
+	"time"
 )
 
 // Parameters for the Open-Meteo Forecast V1 API.
 // These are not exclusive. Check the docs for additional ones.
 // https://open-meteo.com/en/docs
 type ForecastParams struct {
-	Latitude      float64
-	Longitude     float64
+	Latitude  float64
+	Longitude float64
+	// Elevation in meters used to downscale the forecast to the location. The
+	// API uses the terrain elevation when nil. GetForecasts takes it from each
+	// location instead, see Coordinates.
+	Elevation     *float64
 	Timezone      string
 	ForecastHours int
 	ForecastDays  int
-	Current       []CurrentVariables
-	Daily         []DailyVariables
-	Hourly        []HourlyVariables
+	// PastDays and PastHours prepend that many past steps to the forecast.
+	PastDays  int
+	PastHours int
+	// StartDate and EndDate select a range of days instead of the forecast
+	// and past lengths, StartHour and EndHour do the same for hourly data.
+	// Only the date or the hour in the location's timezone is used, zero
+	// values are left out of the request.
+	StartDate time.Time
+	EndDate   time.Time
+	StartHour time.Time
+	EndHour   time.Time
+	// ForecastMinutely15 limits the 15-minute data to this many steps from now.
+	ForecastMinutely15 int
+	Current            []CurrentVariables
+	Daily              []DailyVariables
+	Hourly             []HourlyVariables
+	Minutely15         []Minutely15Variables
+	// Models selects the weather models, the API picks the best match for the
+	// location when empty. Use GetModelForecasts to request several at once.
+	Models []WeatherModel
+	Units  Units
 }
 
 // FloatMeasurement pairs a numeric value with the unit reported by the API.
@@ -28,11 +52,36 @@ type FloatMeasurement struct {
 }
 
 // FloatSeries holds a time series of numeric values and the shared unit.
+// Steps without data, reported as null by the API, hold NaN.
 type FloatSeries struct {
 	Values []float64
 	Unit   string
 }
 
+// At returns the value at index i, false when it is out of range or missing.
+func (s FloatSeries) At(i int) (float64, bool) {
+	if i < 0 || i >= len(s.Values) || math.IsNaN(s.Values[i]) {
+		return 0, false
+	}
+	return s.Values[i], true
+}
+
+// TimeSeries holds a time series of timestamps, such as the daily sunrise and
+// sunset. Values are located in the UTC offset of the forecast location.
+// Steps without data hold the zero time.
+type TimeSeries struct {
+	Values []time.Time
+	Unit   string
+}
+
+// At returns the timestamp at index i, false when it is out of range or missing.
+func (s TimeSeries) At(i int) (time.Time, bool) {
+	if i < 0 || i >= len(s.Values) || s.Values[i].IsZero() {
+		return time.Time{}, false
+	}
+	return s.Values[i], true
+}
+
 // ForecastResponse is a typed view of the Forecast V1 payload. The JSON is
 // decoded into a private representation first, then mapped onto the strongly
 // typed measurement structures below so callers never have to assert from any.
@@ -46,12 +95,20 @@ type ForecastResponse struct {
 	UTCOffsetSeconds int
 	Timezone         string
 	TimezoneAbbrev   string
-	CurrentTime      string
-	HourlyTimes      []string
-	DailyTimes       []string
-	Current          map[CurrentVariables]FloatMeasurement
-	Daily            map[DailyVariables]FloatSeries
-	Hourly           map[HourlyVariables]FloatSeries
+	// Location is the fixed zone of the forecast, all times are located in it.
+	Location        *time.Location
+	CurrentTime     time.Time
+	HourlyTimes     TimeAxis
+	DailyTimes      TimeAxis
+	Minutely15Times TimeAxis
+	Current         map[CurrentVariables]FloatMeasurement
+	Daily           map[DailyVariables]FloatSeries
+	DailyTimeSeries map[DailyVariables]TimeSeries
+	Hourly          map[HourlyVariables]FloatSeries
+	Minutely15      map[Minutely15Variables]FloatSeries
+	// FetchedAt is when the response came from the API, earlier than now when
+	// it was served from the client's cache.
+	FetchedAt time.Time
 }
 
 // CurrentMeasurement retrieves a single current measurement if it was requested.
@@ -72,6 +129,16 @@ func (f ForecastResponse) DailySeries(variable DailyVariables) (FloatSeries, boo
 	return series, ok
 }
 
+// DailyTimestamps retrieves a daily series of timestamps, like sunrise or
+// sunset, if it was requested.
+func (f ForecastResponse) DailyTimestamps(variable DailyVariables) (TimeSeries, bool) {
+	if f.DailyTimeSeries == nil {
+		return TimeSeries{}, false
+	}
+	series, ok := f.DailyTimeSeries[variable]
+	return series, ok
+}
+
 // HourlySeries retrieves an hourly time series if it was requested.
 func (f ForecastResponse) HourlySeries(variable HourlyVariables) (FloatSeries, bool) {
 	if f.Hourly == nil {
@@ -81,76 +148,14 @@ func (f ForecastResponse) HourlySeries(variable HourlyVariables) (FloatSeries, b
 	return series, ok
 }
 
-// Variables available to request from the Open-Meteo Forecast V1 API for current weather.
-type CurrentVariables string
-
-const (
-	CurrentTemperature2m       CurrentVariables = "temperature_2m"
-	CurrentRelativeHumidity2m  CurrentVariables = "relative_humidity_2m"
-	CurrentApparentTemperature CurrentVariables = "apparent_temperature"
-	CurrentIsDay               CurrentVariables = "is_day"
-	CurrentWeatherCode         CurrentVariables = "weather_code"
-	CurrentCloudCover          CurrentVariables = "cloud_cover"
-	CurrentSeaLevelPressure    CurrentVariables = "pressure_msl"
-	CurrentSurfacePressure     CurrentVariables = "surface_pressure"
-	CurrentPrecipitation       CurrentVariables = "precipitation"
-	CurrentRain                CurrentVariables = "rain"
-	CurrentShowers             CurrentVariables = "showers"
-	CurrentSnowfall            CurrentVariables = "snowfall"
-	CurrentWindSpeed10m        CurrentVariables = "wind_speed_10m"
-	CurrentWindDirection10m    CurrentVariables = "wind_direction_10m"
-	CurrentWindGusts10m        CurrentVariables = "wind_gusts_10m"
-)
-
-// Variables available to request from the Open-Meteo Forecast V1 API for daily weather.
-type DailyVariables string
-
-const (
-	DailyTemperature2mMin DailyVariables = "temperature_2m_min"
-	DailyTemperature2mMax DailyVariables = "temperature_2m_max"
-	DailyWeatherCode      DailyVariables = "weathercode"
-	DailyUVIndexMax       DailyVariables = "uv_index_max"
-)
-
-// Variables available to request from the Open-Meteo Forecast V1 API for hourly weather.
-type HourlyVariables string
-
-const (
-	HourlyTemperature2m HourlyVariables = "temperature_2m"
-	HourlyWeatherCode   HourlyVariables = "weathercode"
-	HourlyPrecipitation HourlyVariables = "precipitation"
-)
-
-var (
-	currentVariableLookup = map[string]CurrentVariables{
-		string(CurrentTemperature2m):       CurrentTemperature2m,
-		string(CurrentRelativeHumidity2m):  CurrentRelativeHumidity2m,
-		string(CurrentApparentTemperature): CurrentApparentTemperature,
-		string(CurrentIsDay):               CurrentIsDay,
-		string(CurrentWeatherCode):         CurrentWeatherCode,
-		string(CurrentCloudCover):          CurrentCloudCover,
-		string(CurrentSeaLevelPressure):    CurrentSeaLevelPressure,
-		string(CurrentSurfacePressure):     CurrentSurfacePressure,
-		string(CurrentPrecipitation):       CurrentPrecipitation,
-		string(CurrentRain):                CurrentRain,
-		string(CurrentShowers):             CurrentShowers,
-		string(CurrentSnowfall):            CurrentSnowfall,
-		string(CurrentWindSpeed10m):        CurrentWindSpeed10m,
-		string(CurrentWindDirection10m):    CurrentWindDirection10m,
-		string(CurrentWindGusts10m):        CurrentWindGusts10m,
-	}
-	dailyVariableLookup = map[string]DailyVariables{
-		string(DailyTemperature2mMin): DailyTemperature2mMin,
-		string(DailyTemperature2mMax): DailyTemperature2mMax,
-		string(DailyWeatherCode):      DailyWeatherCode,
-		string(DailyUVIndexMax):       DailyUVIndexMax,
-	}
-	hourlyVariableLookup = map[string]HourlyVariables{
-		string(HourlyTemperature2m): HourlyTemperature2m,
-		string(HourlyWeatherCode):   HourlyWeatherCode,
-		string(HourlyPrecipitation): HourlyPrecipitation,
+// Minutely15Series retrieves a 15-minute time series if it was requested.
+func (f ForecastResponse) Minutely15Series(variable Minutely15Variables) (FloatSeries, bool) {
+	if f.Minutely15 == nil {
+		return FloatSeries{}, false
 	}
-)
+	series, ok := f.Minutely15[variable]
+	return series, ok
+}
 
 const FORECAST_API_URL = "https://api.open-meteo.com/v1/forecast"
 
@@ -168,13 +173,147 @@ type forecastResponseRaw struct {
 	Hourly           map[string][]any  `json:"hourly"`
 	DailyUnits       map[string]string `json:"daily_units"`
 	Daily            map[string][]any  `json:"daily"`
+	Minutely15Units  map[string]string `json:"minutely_15_units"`
+	Minutely15       map[string][]any  `json:"minutely_15"`
 }
 
 // Retrieve the current forecast data for a given location and parameters.
 // Data is provided by the Open-Meteo API. The payload is normalised into
 // typed measurement structs so callers never have to downcast from any.
 func GetForecast(params ForecastParams) (ForecastResponse, error) {
-	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", FORECAST_API_URL, params.Latitude, params.Longitude)
+	return DefaultClient.GetForecast(context.Background(), params)
+}
+
+// GetForecast retrieves the forecast for the given parameters. The request is
+// aborted when ctx is cancelled.
+func (c *Client) GetForecast(ctx context.Context, params ForecastParams) (ForecastResponse, error) {
+	url := forecastURL(c.ForecastURL, params)
+
+	var raw forecastResponseRaw
+	fetchedAt, err := c.getCachedJSON(ctx, url, FORECAST_CACHE_TTL, &raw)
+	if err != nil {
+		return ForecastResponse{}, err
+	}
+
+	forecast := raw.toForecastResponse()
+	forecast.FetchedAt = fetchedAt
+	return forecast, nil
+}
+
+// Coordinates of one of the locations of a batch forecast.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+	// Elevation in meters to downscale the forecast of this location to, the
+	// terrain elevation when nil. Only used by GetForecasts.
+	Elevation *float64
+}
+
+// Retrieve the forecast of several locations in one request.
+// Data is provided by the Open-Meteo API.
+func GetForecasts(locations []Coordinates, params ForecastParams) ([]ForecastResponse, error) {
+	return DefaultClient.GetForecasts(context.Background(), locations, params)
+}
+
+// GetForecasts retrieves the forecast of every location in one request, with
+// the parameters of params other than the coordinates and elevation, which
+// come from locations. The forecasts are in the order of locations. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetForecasts(ctx context.Context, locations []Coordinates, params ForecastParams) ([]ForecastResponse, error) {
+	if params.Elevation != nil {
+		return nil, errors.New("the elevation of a batch forecast is set per location, see Coordinates")
+	}
+
+	switch len(locations) {
+	case 0:
+		return nil, nil
+	case 1:
+		// A single location is answered with an object instead of a list.
+		params.Latitude = locations[0].Latitude
+		params.Longitude = locations[0].Longitude
+		params.Elevation = locations[0].Elevation
+		forecast, err := c.GetForecast(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		return []ForecastResponse{forecast}, nil
+	}
+
+	latitudes := make([]string, len(locations))
+	longitudes := make([]string, len(locations))
+	for i, location := range locations {
+		latitudes[i] = fmt.Sprintf("%f", location.Latitude)
+		longitudes[i] = fmt.Sprintf("%f", location.Longitude)
+	}
+	url := fmt.Sprintf("%s?latitude=%s&longitude=%s", c.ForecastURL, strings.Join(latitudes, ","), strings.Join(longitudes, ","))
+	elevations, err := c.batchElevations(ctx, locations)
+	if err != nil {
+		return nil, err
+	}
+	if elevations != "" {
+		url += "&elevation=" + elevations
+	}
+	url += forecastQuery(params)
+
+	var raws []forecastResponseRaw
+	fetchedAt, err := c.getCachedJSON(ctx, url, FORECAST_CACHE_TTL, &raws)
+	if err != nil {
+		return nil, err
+	}
+	if len(raws) != len(locations) {
+		return nil, fmt.Errorf("open-meteo returned %d forecasts for %d locations", len(raws), len(locations))
+	}
+
+	forecasts := make([]ForecastResponse, len(raws))
+	for i, raw := range raws {
+		forecasts[i] = raw.toForecastResponse()
+		forecasts[i].FetchedAt = fetchedAt
+	}
+	return forecasts, nil
+}
+
+// The elevation list of a batch forecast, empty when no location sets one.
+// The API has no placeholder for the terrain elevation in the list, the
+// locations without one get the terrain elevation from the Elevation API.
+func (c *Client) batchElevations(ctx context.Context, locations []Coordinates) (string, error) {
+	var missing []Coordinates
+	for _, location := range locations {
+		if location.Elevation == nil {
+			missing = append(missing, location)
+		}
+	}
+	if len(missing) == len(locations) {
+		return "", nil
+	}
+	terrain, err := c.GetElevation(ctx, missing)
+	if err != nil {
+		return "", err
+	}
+
+	elevations := make([]string, len(locations))
+	for i, location := range locations {
+		if location.Elevation != nil {
+			elevations[i] = fmt.Sprintf("%f", *location.Elevation)
+			continue
+		}
+		elevations[i] = fmt.Sprintf("%f", terrain[0])
+		terrain = terrain[1:]
+	}
+	return strings.Join(elevations, ","), nil
+}
+
+// Compose the request URL of a forecast.
+func forecastURL(baseURL string, params ForecastParams) string {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", baseURL, params.Latitude, params.Longitude)
+	return url + forecastQuery(params)
+}
+
+// Compose the query parameters of a forecast other than the coordinates, starting with "&".
+func forecastQuery(params ForecastParams) string {
+	var url string
+	if params.Elevation != nil {
+		url += fmt.Sprintf("&elevation=%f", *params.Elevation)
+	}
 	if params.Timezone != "" {
 		url += fmt.Sprintf("&timezone=%s", params.Timezone)
 	}
@@ -184,6 +323,32 @@ func GetForecast(params ForecastParams) (ForecastResponse, error) {
 	if params.ForecastDays > 0 {
 		url += fmt.Sprintf("&forecast_days=%d", params.ForecastDays)
 	}
+	if params.ForecastMinutely15 > 0 {
+		url += fmt.Sprintf("&forecast_minutely_15=%d", params.ForecastMinutely15)
+	}
+	if params.PastDays > 0 {
+		url += fmt.Sprintf("&past_days=%d", params.PastDays)
+	}
+	if params.PastHours > 0 {
+		url += fmt.Sprintf("&past_hours=%d", params.PastHours)
+	}
+	if !params.StartDate.IsZero() {
+		url += fmt.Sprintf("&start_date=%s", params.StartDate.Format(apiDateLayout))
+	}
+	if !params.EndDate.IsZero() {
+		url += fmt.Sprintf("&end_date=%s", params.EndDate.Format(apiDateLayout))
+	}
+	if !params.StartHour.IsZero() {
+		url += fmt.Sprintf("&start_hour=%s", params.StartHour.Format(apiTimeLayout))
+	}
+	if !params.EndHour.IsZero() {
+		url += fmt.Sprintf("&end_hour=%s", params.EndHour.Format(apiTimeLayout))
+	}
+	if len(params.Models) > 0 {
+		models := writeVariableCSV(params.Models)
+		url += fmt.Sprintf("&models=%s", models)
+	}
+	url += writeUnitsQuery(params.Units)
 	if len(params.Current) > 0 {
 		currentVars := writeVariableCSV(params.Current)
 		url += fmt.Sprintf("&current=%s", currentVars)
@@ -196,22 +361,11 @@ func GetForecast(params ForecastParams) (ForecastResponse, error) {
 		hourlyVars := writeVariableCSV(params.Hourly)
 		url += fmt.Sprintf("&hourly=%s", hourlyVars)
 	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return ForecastResponse{}, err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return ForecastResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-	decoder := json.NewDecoder(resp.Body)
-	var raw forecastResponseRaw
-	if err := decoder.Decode(&raw); err != nil {
-		return ForecastResponse{}, err
+	if len(params.Minutely15) > 0 {
+		minutely15Vars := writeVariableCSV(params.Minutely15)
+		url += fmt.Sprintf("&minutely_15=%s", minutely15Vars)
 	}
-
-	return raw.toForecastResponse(), nil
+	return url
 }
 
 // Compose a comma-separated string of variable names.
@@ -225,6 +379,7 @@ func writeVariableCSV[T ~string](variables []T) string {
 }
 
 func (raw forecastResponseRaw) toForecastResponse() ForecastResponse {
+	location := raw.location()
 	response := ForecastResponse{
 		Latitude:         raw.Latitude,
 		Longitude:        raw.Longitude,
@@ -233,50 +388,34 @@ func (raw forecastResponseRaw) toForecastResponse() ForecastResponse {
 		UTCOffsetSeconds: raw.UTCOffsetSeconds,
 		Timezone:         raw.Timezone,
 		TimezoneAbbrev:   raw.TimezoneAbbrev,
-		Current:          make(map[CurrentVariables]FloatMeasurement),
+		Location:         location,
+		CurrentTime:      raw.currentTime(),
+		HourlyTimes:      raw.hourlyTimes(),
+		DailyTimes:       raw.dailyTimes(),
+		Minutely15Times:  raw.minutely15Times(),
+		Current:          toMeasurements(raw.Current, raw.CurrentUnits, currentVariableLookup),
 		Daily:            make(map[DailyVariables]FloatSeries),
-		Hourly:           make(map[HourlyVariables]FloatSeries),
-	}
-
-	if raw.Current != nil {
-		if currentTime, ok := raw.Current["time"].(string); ok {
-			response.CurrentTime = currentTime
-		}
-	}
-	if raw.Daily != nil {
-		if times, ok := toStringSlice(raw.Daily["time"]); ok {
-			response.DailyTimes = times
-		}
-	}
-	if raw.Hourly != nil {
-		if times, ok := toStringSlice(raw.Hourly["time"]); ok {
-			response.HourlyTimes = times
-		}
-	}
-
-	if raw.Current != nil && raw.CurrentUnits != nil {
-		for key, unit := range raw.CurrentUnits {
-			variable, ok := currentVariableLookup[key]
-			if !ok {
-				continue
-			}
-			value, ok := toFloat64(raw.Current[key])
-			if !ok {
-				continue
-			}
-			response.Current[variable] = FloatMeasurement{
-				Value: value,
-				Unit:  unit,
-			}
-		}
+		DailyTimeSeries:  make(map[DailyVariables]TimeSeries),
+		Hourly:           toSeries(raw.Hourly, raw.HourlyUnits, hourlyVariableLookup),
+		Minutely15:       toSeries(raw.Minutely15, raw.Minutely15Units, minutely15VariableLookup),
 	}
 
+	// Daily data mixes numeric series with timestamps such as sunrise and sunset.
 	if raw.Daily != nil && raw.DailyUnits != nil {
 		for key, unit := range raw.DailyUnits {
 			variable, ok := dailyVariableLookup[key]
 			if !ok {
 				continue
 			}
+			if unit == ISO8601_UNIT {
+				if times, ok := toTimeSlice(raw.Daily[key], location); ok {
+					response.DailyTimeSeries[variable] = TimeSeries{
+						Values: times,
+						Unit:   unit,
+					}
+				}
+				continue
+			}
 			values, ok := toFloatSlice(raw.Daily[key])
 			if !ok {
 				continue
@@ -288,24 +427,144 @@ func (raw forecastResponseRaw) toForecastResponse() ForecastResponse {
 		}
 	}
 
-	if raw.Hourly != nil && raw.HourlyUnits != nil {
-		for key, unit := range raw.HourlyUnits {
-			variable, ok := hourlyVariableLookup[key]
-			if !ok {
-				continue
-			}
-			values, ok := toFloatSlice(raw.Hourly[key])
-			if !ok {
-				continue
-			}
-			response.Hourly[variable] = FloatSeries{
-				Values: values,
-				Unit:   unit,
-			}
+	return response
+}
+
+func (raw forecastResponseRaw) currentTime() time.Time {
+	if raw.Current == nil {
+		return time.Time{}
+	}
+	currentTime, ok := raw.Current["time"].(string)
+	if !ok {
+		return time.Time{}
+	}
+	t, err := parseLocalTime(currentTime, raw.location())
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func (raw forecastResponseRaw) hourlyTimes() TimeAxis {
+	if raw.Hourly == nil {
+		return nil
+	}
+	times, _ := toTimeSlice(raw.Hourly["time"], raw.location())
+	return times
+}
+
+func (raw forecastResponseRaw) dailyTimes() TimeAxis {
+	if raw.Daily == nil {
+		return nil
+	}
+	times, _ := toTimeSlice(raw.Daily["time"], raw.location())
+	return times
+}
+
+func (raw forecastResponseRaw) minutely15Times() TimeAxis {
+	if raw.Minutely15 == nil {
+		return nil
+	}
+	times, _ := toTimeSlice(raw.Minutely15["time"], raw.location())
+	return times
+}
+
+// Map the numeric values of a "current" object onto the known variables.
+func toMeasurements[T ~string](values map[string]any, units map[string]string, lookup map[string]T) map[T]FloatMeasurement {
+	measurements := make(map[T]FloatMeasurement)
+	if values == nil || units == nil {
+		return measurements
+	}
+	for key, unit := range units {
+		variable, ok := lookup[key]
+		if !ok {
+			continue
+		}
+		value, ok := toFloat64(values[key])
+		if !ok {
+			continue
+		}
+		measurements[variable] = FloatMeasurement{
+			Value: value,
+			Unit:  unit,
+		}
+	}
+	return measurements
+}
+
+// Map the numeric series of an "hourly", "daily" or "minutely_15" object onto the known variables.
+func toSeries[T ~string](values map[string][]any, units map[string]string, lookup map[string]T) map[T]FloatSeries {
+	series := make(map[T]FloatSeries)
+	if values == nil || units == nil {
+		return series
+	}
+	for key, unit := range units {
+		variable, ok := lookup[key]
+		if !ok {
+			continue
+		}
+		floats, ok := toFloatSlice(values[key])
+		if !ok {
+			continue
+		}
+		series[variable] = FloatSeries{
+			Values: floats,
+			Unit:   unit,
 		}
 	}
+	return series
+}
 
-	return response
+// ISO8601_UNIT is the unit the API reports for timestamp variables.
+const ISO8601_UNIT = "iso8601"
+
+// Layouts of the local timestamps in the payload, with and without the time of day.
+const (
+	apiTimeLayout = "2006-01-02T15:04"
+	apiDateLayout = "2006-01-02"
+)
+
+// The fixed zone of the forecast location. Timestamps in the payload are local
+// to this offset and carry no zone information of their own.
+func (raw forecastResponseRaw) location() *time.Location {
+	name := raw.TimezoneAbbrev
+	if name == "" {
+		name = raw.Timezone
+	}
+	return time.FixedZone(name, raw.UTCOffsetSeconds)
+}
+
+// Null entries become the zero time instead of invalidating the whole series.
+func toTimeSlice(values []any, loc *time.Location) ([]time.Time, bool) {
+	if values == nil {
+		return nil, false
+	}
+	result := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			result = append(result, time.Time{})
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		t, err := parseLocalTime(str, loc)
+		if err != nil {
+			return nil, false
+		}
+		result = append(result, t)
+	}
+	return result, true
+}
+
+// Parse a timestamp from the payload, which may or may not include the time of day.
+func parseLocalTime(value string, loc *time.Location) (time.Time, error) {
+	layout := apiTimeLayout
+	if len(value) == len(apiDateLayout) {
+		layout = apiDateLayout
+	}
+	return time.ParseInLocation(layout, value, loc)
 }
 
 func toFloat64(value any) (float64, bool) {
@@ -331,12 +590,17 @@ func toFloat64(value any) (float64, bool) {
 	}
 }
 
+// Null entries become NaN instead of invalidating the whole series.
 func toFloatSlice(values []any) ([]float64, bool) {
 	if values == nil {
 		return nil, false
 	}
 	result := make([]float64, 0, len(values))
 	for _, value := range values {
+		if value == nil {
+			result = append(result, math.NaN())
+			continue
+		}
 		floatVal, ok := toFloat64(value)
 		if !ok {
 			return nil, false
@@ -345,18 +609,3 @@ func toFloatSlice(values []any) ([]float64, bool) {
 	}
 	return result, true
 }
-
-func toStringSlice(values []any) ([]string, bool) {
-	if values == nil {
-		return nil, false
-	}
-	result := make([]string, 0, len(values))
-	for _, value := range values {
-		if str, ok := value.(string); ok {
-			result = append(result, str)
-			continue
-		}
-		return nil, false
-	}
-	return result, true
-}
@@ -0,0 +1,175 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestForecastQuery(t *testing.T) {
+	elevation := 1420.0
+	params := ForecastParams{
+		Elevation:          &elevation,
+		Timezone:           "auto",
+		ForecastHours:      24,
+		ForecastDays:       7,
+		ForecastMinutely15: 9,
+		PastDays:           2,
+		Models:             []WeatherModel{"ecmwf_ifs025"},
+		Units:              ImperialUnits,
+		Current:            []CurrentVariables{CurrentTemperature2m, CurrentWeatherCode},
+		Daily:              []DailyVariables{DailyTemperature2mMax},
+		Hourly:             []HourlyVariables{HourlyTemperature2m},
+		Minutely15:         []Minutely15Variables{Minutely15Precipitation},
+	}
+
+	want := "&elevation=1420.000000&timezone=auto&forecast_hours=24&forecast_days=7" +
+		"&forecast_minutely_15=9&past_days=2&models=ecmwf_ifs025" +
+		"&temperature_unit=fahrenheit&wind_speed_unit=mph&precipitation_unit=inch" +
+		"&current=temperature_2m,weather_code&daily=temperature_2m_max" +
+		"&hourly=temperature_2m&minutely_15=precipitation"
+	if got := forecastQuery(params); got != want {
+		t.Errorf("forecastQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestForecastQueryLeavesOutZeroValues(t *testing.T) {
+	if got := forecastQuery(ForecastParams{}); got != "" {
+		t.Errorf("forecastQuery() = %q, want an empty query", got)
+	}
+}
+
+const forecastPayload = `{
+	"latitude": 46.0, "longitude": 7.0, "elevation": 1420,
+	"utc_offset_seconds": 7200, "timezone": "Europe/Zurich", "timezone_abbreviation": "CEST",
+	"current_units": {"time": "iso8601", "temperature_2m": "°C", "weather_code": "wmo code", "unknown_variable": "?"},
+	"current": {"time": "2026-10-17T12:00", "temperature_2m": 14.2, "weather_code": null, "unknown_variable": 1},
+	"hourly_units": {"time": "iso8601", "temperature_2m": "°C"},
+	"hourly": {"time": ["2026-10-17T12:00", "2026-10-17T13:00", "2026-10-17T14:00"], "temperature_2m": [14.2, null, 15.1]},
+	"daily_units": {"time": "iso8601", "temperature_2m_max": "°C", "sunrise": "iso8601"},
+	"daily": {"time": ["2026-10-17"], "temperature_2m_max": [16.0], "sunrise": ["2026-10-17T07:45"]}
+}`
+
+func TestToForecastResponseHandlesNulls(t *testing.T) {
+	var raw forecastResponseRaw
+	if err := json.Unmarshal([]byte(forecastPayload), &raw); err != nil {
+		t.Fatal(err)
+	}
+	forecast := raw.toForecastResponse()
+
+	zone := time.FixedZone("CEST", 7200)
+	if want := time.Date(2026, 10, 17, 12, 0, 0, 0, zone); !forecast.CurrentTime.Equal(want) {
+		t.Errorf("got current time %v, want %v", forecast.CurrentTime, want)
+	}
+	if temperature, ok := forecast.CurrentMeasurement(CurrentTemperature2m); !ok || temperature.Value != 14.2 || temperature.Unit != "°C" {
+		t.Errorf("got current temperature %+v, %v", temperature, ok)
+	}
+	// A null current value is left out, like an unknown variable.
+	if _, ok := forecast.CurrentMeasurement(CurrentWeatherCode); ok {
+		t.Errorf("got a weather code for a null value")
+	}
+	if len(forecast.Current) != 1 {
+		t.Errorf("got %d current measurements, want 1", len(forecast.Current))
+	}
+
+	hourly, ok := forecast.HourlySeries(HourlyTemperature2m)
+	if !ok || len(hourly.Values) != 3 {
+		t.Fatalf("got hourly temperatures %+v, %v", hourly, ok)
+	}
+	// A null step keeps its place in the series, as NaN.
+	if !math.IsNaN(hourly.Values[1]) {
+		t.Errorf("got %v for a null step, want NaN", hourly.Values[1])
+	}
+	if _, ok := hourly.At(1); ok {
+		t.Errorf("At reported a value for a null step")
+	}
+	if value, ok := hourly.At(2); !ok || value != 15.1 {
+		t.Errorf("got %v, %v after the null step, want 15.1", value, ok)
+	}
+	if len(forecast.HourlyTimes) != 3 {
+		t.Errorf("got %d hourly times, want 3", len(forecast.HourlyTimes))
+	}
+
+	if sunrise, ok := forecast.DailyTimestamps(DailySunrise); !ok || sunrise.Values[0].Hour() != 7 {
+		t.Errorf("got sunrise %+v, %v", sunrise, ok)
+	}
+	if _, ok := forecast.DailySeries(DailySunrise); ok {
+		t.Errorf("sunrise should only be a timestamp series")
+	}
+}
+
+func TestGetForecastRequestsParams(t *testing.T) {
+	var query string
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(forecastPayload))
+	})
+	client.ForecastURL = url
+
+	forecast, err := client.GetForecast(context.Background(), ForecastParams{
+		Latitude:  46,
+		Longitude: 7,
+		Hourly:    []HourlyVariables{HourlyTemperature2m},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "latitude=46.000000&longitude=7.000000&hourly=temperature_2m"; query != want {
+		t.Errorf("got query %q, want %q", query, want)
+	}
+	if forecast.Elevation != 1420 {
+		t.Errorf("got elevation %v, want 1420", forecast.Elevation)
+	}
+	if forecast.FetchedAt.IsZero() {
+		t.Errorf("FetchedAt is not set")
+	}
+}
+
+func TestGetForecastsSetsElevationPerLocation(t *testing.T) {
+	var forecastQuery, elevationQuery string
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/elevation":
+			elevationQuery = r.URL.RawQuery
+			w.Write([]byte(`{"elevation":[372.0]}`))
+		default:
+			forecastQuery = r.URL.RawQuery
+			w.Write([]byte(`[{"latitude":46,"longitude":7},{"latitude":47,"longitude":8}]`))
+		}
+	})
+	client.ForecastURL = url + "/forecast"
+	client.ElevationURL = url + "/elevation"
+
+	override := 2400.0
+	locations := []Coordinates{
+		{Latitude: 46, Longitude: 7, Elevation: &override},
+		{Latitude: 47, Longitude: 8},
+	}
+	if _, err := client.GetForecasts(context.Background(), locations, ForecastParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The location without an override keeps its terrain elevation.
+	if want := "latitude=47.000000&longitude=8.000000"; elevationQuery != want {
+		t.Errorf("got elevation query %q, want %q", elevationQuery, want)
+	}
+	if want := "latitude=46.000000,47.000000&longitude=7.000000,8.000000&elevation=2400.000000,372.000000"; forecastQuery != want {
+		t.Errorf("got forecast query %q, want %q", forecastQuery, want)
+	}
+}
+
+func TestGetForecastsRejectsSharedElevation(t *testing.T) {
+	client, url := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	client.ForecastURL = url
+
+	elevation := 1000.0
+	locations := []Coordinates{{Latitude: 46, Longitude: 7}, {Latitude: 47, Longitude: 8}}
+	if _, err := client.GetForecasts(context.Background(), locations, ForecastParams{Elevation: &elevation}); err == nil {
+		t.Errorf("got no error for an elevation shared by every location")
+	}
+}
@@ -1,11 +1,11 @@
 package openmeteo
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Parameters for the Open-Meteo Geocoding V1 API.
@@ -14,29 +14,58 @@ import (
 type GeocodingParams struct {
 	Name  string
 	Count int
+	// Language of the returned names, e.g. "en" or "de". English when empty.
+	Language string
+	// CountryCode limits the results to an ISO-3166-1 alpha2 country code, e.g. "US".
+	CountryCode string
 }
 
-// Result item in response array.
+// Result item in response array. The administrative areas go from the
+// largest, Admin1, to the smallest, Admin4, and are empty when unknown.
 type GeocodingResult struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	Country   string  `json:"country"`
-	Admin1    string  `json:"admin1"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	CountryCode string   `json:"country_code,omitempty"`
+	Admin1      string   `json:"admin1"`
+	Admin2      string   `json:"admin2,omitempty"`
+	Admin3      string   `json:"admin3,omitempty"`
+	Admin4      string   `json:"admin4,omitempty"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Elevation   float64  `json:"elevation,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"`
+	Population  int      `json:"population,omitempty"`
+	Postcodes   []string `json:"postcodes,omitempty"`
+	// FeatureCode is the GeoNames feature code, e.g. "PPLC" for a capital.
+	FeatureCode string `json:"feature_code,omitempty"`
+	// ElevationOverride is an elevation in meters set by the user for the
+	// forecast, in place of the terrain elevation. Not part of the API.
+	ElevationOverride *float64 `json:"elevation_override,omitempty"`
 }
 
 // Response from the Open-Meteo Geocoding V1 API.
 type GeocodingResponse struct {
 	Results []GeocodingResult `json:"results"`
+	// FetchedAt is when the response came from the API, see ForecastResponse.
+	FetchedAt time.Time `json:"-"`
 }
 
-const GEOCODING_API_URL = "https://geocoding-api.open-meteo.com/v1/search"
+const (
+	GEOCODING_API_URL = "https://geocoding-api.open-meteo.com/v1/search"
+	LOCATION_API_URL  = "https://geocoding-api.open-meteo.com/v1/get"
+)
 
 // Gets a list of location matches based on the submitted name.
 // Data is provided by the Open-Meteo API.
 func SearchLocation(params GeocodingParams) (GeocodingResponse, error) {
-	searchURL, err := url.Parse(GEOCODING_API_URL)
+	return DefaultClient.SearchLocation(context.Background(), params)
+}
+
+// SearchLocation gets a list of location matches based on the submitted name.
+// The request is aborted when ctx is cancelled.
+func (c *Client) SearchLocation(ctx context.Context, params GeocodingParams) (GeocodingResponse, error) {
+	searchURL, err := url.Parse(c.GeocodingURL)
 	if err != nil {
 		return GeocodingResponse{}, fmt.Errorf("failed to parse geocoding url: %w", err)
 	}
@@ -46,24 +75,47 @@ func SearchLocation(params GeocodingParams) (GeocodingResponse, error) {
 	if params.Count != 0 {
 		query.Set("count", strconv.Itoa(params.Count))
 	}
+	if params.Language != "" {
+		query.Set("language", params.Language)
+	}
+	if params.CountryCode != "" {
+		query.Set("countryCode", params.CountryCode)
+	}
 	searchURL.RawQuery = query.Encode()
 
-	resp, err := http.Get(searchURL.String())
+	var response GeocodingResponse
+	fetchedAt, err := c.getCachedJSON(ctx, searchURL.String(), GEOCODING_CACHE_TTL, &response)
 	if err != nil {
 		return GeocodingResponse{}, err
 	}
-	defer resp.Body.Close()
+	response.FetchedAt = fetchedAt
 
-	if resp.StatusCode != http.StatusOK {
-		return GeocodingResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+	return response, nil
+}
 
-	decoder := json.NewDecoder(resp.Body)
-	var response GeocodingResponse
-	err = decoder.Decode(&response)
+// Gets a single location by its geocoding ID, as found in GeocodingResult.ID.
+// Data is provided by the Open-Meteo API.
+func GetLocationByID(id int) (GeocodingResult, error) {
+	return DefaultClient.GetLocationByID(context.Background(), id)
+}
+
+// GetLocationByID gets a single location by its geocoding ID. The request is
+// aborted when ctx is cancelled.
+func (c *Client) GetLocationByID(ctx context.Context, id int) (GeocodingResult, error) {
+	locationURL, err := url.Parse(c.LocationURL)
 	if err != nil {
-		return GeocodingResponse{}, err
+		return GeocodingResult{}, fmt.Errorf("failed to parse location url: %w", err)
 	}
 
-	return response, nil
+	query := locationURL.Query()
+	query.Set("id", strconv.Itoa(id))
+	locationURL.RawQuery = query.Encode()
+
+	// The location is returned on its own, not wrapped in a results list.
+	var result GeocodingResult
+	if _, err := c.getCachedJSON(ctx, locationURL.String(), GEOCODING_CACHE_TTL, &result); err != nil {
+		return GeocodingResult{}, err
+	}
+
+	return result, nil
 }
@@ -0,0 +1,109 @@
+// Command genvariables generates the variable constants, catalogs and lookups
+// of the openmeteo package from variables.txt, see that file for the format.
+// It runs in the package directory through go generate.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	TABLE_FILE  = "variables.txt"
+	OUTPUT_FILE = "variables_gen.go"
+)
+
+type variable struct {
+	name    string
+	apiName string
+}
+
+type group struct {
+	typeName  string
+	variables []variable
+}
+
+func main() {
+	groups, err := readTable(TABLE_FILE)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate %s: %v\n", OUTPUT_FILE, err)
+		os.Exit(1)
+	}
+	source, err := format.Source(generate(groups))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate %s: %v\n", OUTPUT_FILE, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(OUTPUT_FILE, source, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate %s: %v\n", OUTPUT_FILE, err)
+		os.Exit(1)
+	}
+}
+
+func readTable(path string) ([]group, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var groups []group
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if !unicode.IsSpace(rune(text[0])) {
+			if len(fields) != 1 || !strings.HasSuffix(fields[0], "Variables") {
+				return nil, fmt.Errorf("%s:%d: expected a type name ending in Variables", path, line)
+			}
+			groups = append(groups, group{typeName: fields[0]})
+			continue
+		}
+		if len(groups) == 0 || len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a constant name and an API name under a type", path, line)
+		}
+		current := &groups[len(groups)-1]
+		key := current.typeName + " " + fields[1]
+		if seen[key] {
+			return nil, fmt.Errorf("%s:%d: %s is listed twice for %s", path, line, fields[1], current.typeName)
+		}
+		seen[key] = true
+		current.variables = append(current.variables, variable{name: fields[0], apiName: fields[1]})
+	}
+	return groups, scanner.Err()
+}
+
+func generate(groups []group) []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by genvariables from %s; DO NOT EDIT.\n\npackage openmeteo\n", TABLE_FILE)
+	for _, g := range groups {
+		prefix := strings.TrimSuffix(g.typeName, "Variables")
+		catalog := strings.TrimSuffix(g.typeName, "s") + "Catalog"
+		lookup := strings.ToLower(catalog[:1]) + strings.TrimSuffix(catalog[1:], "Catalog") + "Lookup"
+
+		fmt.Fprintf(&out, "\nconst (\n")
+		for _, v := range g.variables {
+			fmt.Fprintf(&out, "\t%s%s %s = %q\n", prefix, v.name, g.typeName, v.apiName)
+		}
+		fmt.Fprintf(&out, ")\n\n")
+
+		fmt.Fprintf(&out, "// %s lists every %s value, in the order of %s.\n", catalog, g.typeName, TABLE_FILE)
+		fmt.Fprintf(&out, "var %s = []%s{\n", catalog, g.typeName)
+		for _, v := range g.variables {
+			fmt.Fprintf(&out, "\t%s%s,\n", prefix, v.name)
+		}
+		fmt.Fprintf(&out, "}\n\n")
+
+		fmt.Fprintf(&out, "var %s = newVariableLookup(%s)\n", lookup, catalog)
+	}
+	return out.Bytes()
+}
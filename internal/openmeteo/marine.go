@@ -0,0 +1,165 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Parameters for the Open-Meteo Marine Weather V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/marine-weather-api
+type MarineParams struct {
+	Latitude      float64
+	Longitude     float64
+	Timezone      string
+	ForecastHours int
+	ForecastDays  int
+	// LengthUnit selects metric (default) or imperial wave heights.
+	LengthUnit LengthUnit
+	// TemperatureUnit of the sea surface temperature, Celsius when empty.
+	TemperatureUnit TemperatureUnit
+	Current         []MarineVariables
+	Hourly          []MarineVariables
+	Daily           []MarineDailyVariables
+}
+
+// Variables available to request from the Open-Meteo Marine Weather V1 API for
+// current and hourly data.
+type MarineVariables string
+
+// Variables available to request from the Open-Meteo Marine Weather V1 API for daily data.
+type MarineDailyVariables string
+
+// MarineResponse is a typed view of the Marine Weather V1 payload, mapped
+// like ForecastResponse.
+type MarineResponse struct {
+	Latitude         float64
+	Longitude        float64
+	GenerationTimeMs float64
+	UTCOffsetSeconds int
+	Timezone         string
+	TimezoneAbbrev   string
+	Location         *time.Location
+	CurrentTime      time.Time
+	HourlyTimes      TimeAxis
+	DailyTimes       TimeAxis
+	Current          map[MarineVariables]FloatMeasurement
+	Hourly           map[MarineVariables]FloatSeries
+	Daily            map[MarineDailyVariables]FloatSeries
+}
+
+// CurrentMeasurement retrieves a single current measurement if it was requested.
+func (m MarineResponse) CurrentMeasurement(variable MarineVariables) (FloatMeasurement, bool) {
+	if m.Current == nil {
+		return FloatMeasurement{}, false
+	}
+	measurement, ok := m.Current[variable]
+	return measurement, ok
+}
+
+// HourlySeries retrieves an hourly time series if it was requested.
+func (m MarineResponse) HourlySeries(variable MarineVariables) (FloatSeries, bool) {
+	if m.Hourly == nil {
+		return FloatSeries{}, false
+	}
+	series, ok := m.Hourly[variable]
+	return series, ok
+}
+
+// DailySeries retrieves a daily time series if it was requested.
+func (m MarineResponse) DailySeries(variable MarineDailyVariables) (FloatSeries, bool) {
+	if m.Daily == nil {
+		return FloatSeries{}, false
+	}
+	series, ok := m.Daily[variable]
+	return series, ok
+}
+
+// HasData reports whether the response holds any value. The API answers for
+// inland coordinates too, but every value is null there, so this tells
+// whether the location is coastal.
+func (m MarineResponse) HasData() bool {
+	if len(m.Current) > 0 {
+		return true
+	}
+	for _, series := range m.Hourly {
+		for _, value := range series.Values {
+			if !math.IsNaN(value) {
+				return true
+			}
+		}
+	}
+	for _, series := range m.Daily {
+		for _, value := range series.Values {
+			if !math.IsNaN(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const MARINE_API_URL = "https://marine-api.open-meteo.com/v1/marine"
+
+// Retrieve the marine forecast for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetMarine(params MarineParams) (MarineResponse, error) {
+	return DefaultClient.GetMarine(context.Background(), params)
+}
+
+// GetMarine retrieves the marine forecast for the given parameters. The
+// request is aborted when ctx is cancelled.
+func (c *Client) GetMarine(ctx context.Context, params MarineParams) (MarineResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", c.MarineURL, params.Latitude, params.Longitude)
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
+	}
+	if params.ForecastHours > 0 {
+		url += fmt.Sprintf("&forecast_hours=%d", params.ForecastHours)
+	}
+	if params.ForecastDays > 0 {
+		url += fmt.Sprintf("&forecast_days=%d", params.ForecastDays)
+	}
+	if params.LengthUnit != "" {
+		url += fmt.Sprintf("&length_unit=%s", params.LengthUnit)
+	}
+	if params.TemperatureUnit != "" {
+		url += fmt.Sprintf("&temperature_unit=%s", params.TemperatureUnit)
+	}
+	if len(params.Current) > 0 {
+		currentVars := writeVariableCSV(params.Current)
+		url += fmt.Sprintf("&current=%s", currentVars)
+	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+
+	// The payload has the same layout as a forecast.
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return MarineResponse{}, err
+	}
+
+	return MarineResponse{
+		Latitude:         raw.Latitude,
+		Longitude:        raw.Longitude,
+		GenerationTimeMs: raw.GenerationTimeMs,
+		UTCOffsetSeconds: raw.UTCOffsetSeconds,
+		Timezone:         raw.Timezone,
+		TimezoneAbbrev:   raw.TimezoneAbbrev,
+		Location:         raw.location(),
+		CurrentTime:      raw.currentTime(),
+		HourlyTimes:      raw.hourlyTimes(),
+		DailyTimes:       raw.dailyTimes(),
+		Current:          toMeasurements(raw.Current, raw.CurrentUnits, marineVariableLookup),
+		Hourly:           toSeries(raw.Hourly, raw.HourlyUnits, marineVariableLookup),
+		Daily:            toSeries(raw.Daily, raw.DailyUnits, marineDailyVariableLookup),
+	}, nil
+}
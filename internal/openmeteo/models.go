@@ -0,0 +1,135 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Weather models accepted by the models parameter of the Forecast V1 API.
+// The seamless variants combine the regional and global models of a provider.
+type WeatherModel string
+
+const (
+	BestMatch   WeatherModel = "best_match"
+	ECMWF       WeatherModel = "ecmwf_ifs025"
+	GFS         WeatherModel = "gfs_seamless"
+	ICON        WeatherModel = "icon_seamless"
+	GEM         WeatherModel = "gem_seamless"
+	MeteoFrance WeatherModel = "meteofrance_seamless"
+	JMA         WeatherModel = "jma_seamless"
+	UKMO        WeatherModel = "ukmo_seamless"
+)
+
+// WeatherModelCatalog lists every WeatherModel value, BestMatch first.
+var WeatherModelCatalog = []WeatherModel{
+	BestMatch,
+	ECMWF,
+	GFS,
+	ICON,
+	GEM,
+	MeteoFrance,
+	JMA,
+	UKMO,
+}
+
+// Label is a short display name for the model.
+func (m WeatherModel) Label() string {
+	switch m {
+	case BestMatch, "":
+		return "Best match"
+	case ECMWF:
+		return "ECMWF"
+	case GFS:
+		return "GFS"
+	case ICON:
+		return "ICON"
+	case GEM:
+		return "GEM"
+	case MeteoFrance:
+		return "Météo-France"
+	case JMA:
+		return "JMA"
+	case UKMO:
+		return "UK Met Office"
+	default:
+		return string(m)
+	}
+}
+
+// Next returns the model after m in the catalog, wrapping around. The empty
+// model is the API default, BestMatch.
+func (m WeatherModel) Next() WeatherModel {
+	if m == "" {
+		m = BestMatch
+	}
+	for i, model := range WeatherModelCatalog {
+		if model == m {
+			return WeatherModelCatalog[(i+1)%len(WeatherModelCatalog)]
+		}
+	}
+	return WeatherModelCatalog[0]
+}
+
+// ParseWeatherModel reads a model by its API name or label, case-insensitive.
+func ParseWeatherModel(value string) (WeatherModel, error) {
+	for _, model := range WeatherModelCatalog {
+		if strings.EqualFold(value, string(model)) || strings.EqualFold(value, model.Label()) {
+			return model, nil
+		}
+	}
+	return "", fmt.Errorf("unknown weather model %q", value)
+}
+
+// GetModelForecasts retrieves the forecast of several models in one request.
+// params.Models lists the models, the response of each is keyed by model.
+func (c *Client) GetModelForecasts(ctx context.Context, params ForecastParams) (map[WeatherModel]ForecastResponse, error) {
+	url := forecastURL(c.ForecastURL, params)
+
+	var raw forecastResponseRaw
+	if err := c.getJSON(ctx, url, &raw); err != nil {
+		return nil, err
+	}
+
+	// With a single model the keys carry no suffix.
+	if len(params.Models) == 1 {
+		return map[WeatherModel]ForecastResponse{
+			params.Models[0]: raw.toForecastResponse(),
+		}, nil
+	}
+
+	forecasts := make(map[WeatherModel]ForecastResponse, len(params.Models))
+	for _, model := range params.Models {
+		forecasts[model] = raw.forModel(model).toForecastResponse()
+	}
+	return forecasts, nil
+}
+
+// When several models are requested, every variable comes back once per
+// model with the model name as suffix, e.g. "temperature_2m_max_gfs_seamless".
+// This keeps the keys of one model, without the suffix.
+func (raw forecastResponseRaw) forModel(model WeatherModel) forecastResponseRaw {
+	suffix := "_" + string(model)
+	picked := raw
+	picked.Current, picked.CurrentUnits = pickSuffixed(raw.Current, raw.CurrentUnits, suffix)
+	picked.Hourly, picked.HourlyUnits = pickSuffixed(raw.Hourly, raw.HourlyUnits, suffix)
+	picked.Daily, picked.DailyUnits = pickSuffixed(raw.Daily, raw.DailyUnits, suffix)
+	picked.Minutely15, picked.Minutely15Units = pickSuffixed(raw.Minutely15, raw.Minutely15Units, suffix)
+	return picked
+}
+
+// Keep the entries whose key ends with suffix, trimmed, along with the shared time steps.
+func pickSuffixed[V any](values map[string]V, units map[string]string, suffix string) (map[string]V, map[string]string) {
+	if values == nil || units == nil {
+		return values, units
+	}
+	pickedValues := map[string]V{"time": values["time"]}
+	pickedUnits := make(map[string]string)
+	for key, unit := range units {
+		if name, ok := strings.CutSuffix(key, suffix); ok {
+			pickedValues[name] = values[key]
+			pickedUnits[name] = unit
+		}
+	}
+	return pickedValues, pickedUnits
+}
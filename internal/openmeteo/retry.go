@@ -0,0 +1,143 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are repeated. Only retryable
+// errors (see IsRetryable) are repeated, using exponential backoff with full
+// jitter. A Retry-After header sent by the API takes precedence over the
+// computed delay.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	// MaxDelay caps the wait between attempts. If the API asks to wait longer
+	// than this the request fails instead of blocking.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used by clients created with NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// Compute the wait before the given retry (1 for the first retry).
+func (p RetryPolicy) backoff(retry int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		return apiErr.RetryAfter, true
+	}
+
+	ceiling := p.BaseDelay << (retry - 1)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0, true
+	}
+	return rand.N(ceiling) + 1, true
+}
+
+// Parse a Retry-After header, either delay seconds or an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// Block for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Limiter is a client side token bucket. Every request attempt takes one
+// token, tokens are refilled at a steady rate up to the bucket size. It keeps
+// bursts of refreshes from eating into the Open-Meteo usage limits.
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    int
+	tokens   float64
+	last     time.Time
+}
+
+// NewLimiter returns a full bucket of burst tokens refilled one every interval.
+func NewLimiter(interval time.Duration, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+	}
+}
+
+// DEFAULT_LIMITER_INTERVAL and DEFAULT_LIMITER_BURST keep a session well
+// below the Open-Meteo daily quota while allowing a handful of calls at once.
+const DEFAULT_LIMITER_INTERVAL = 2 * time.Second
+const DEFAULT_LIMITER_BURST = 20
+
+// Wait takes a token, blocking until one is available or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(time.Now())
+		if delay == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// Take a token if one is available, otherwise report how long until the next one.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.interval <= 0 {
+		return 0
+	}
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
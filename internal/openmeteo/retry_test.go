@@ -0,0 +1,97 @@
+package openmeteo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffStaysWithinCeiling(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	tests := []struct {
+		retry   int
+		ceiling time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 300 * time.Millisecond},
+		{10, 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for range 100 {
+			delay, ok := policy.backoff(tt.retry, ErrNetwork)
+			if !ok || delay <= 0 || delay > tt.ceiling {
+				t.Fatalf("retry %d: got %v, %v, want a delay in (0, %v]", tt.retry, delay, ok, tt.ceiling)
+			}
+		}
+	}
+}
+
+func TestBackoffUsesRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+
+	delay, ok := policy.backoff(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 30 * time.Second})
+	if !ok || delay != 30*time.Second {
+		t.Errorf("got %v, %v, want 30s", delay, ok)
+	}
+
+	_, ok = policy.backoff(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour})
+	if ok {
+		t.Errorf("a Retry-After beyond MaxDelay should give up")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"0", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		if got := parseRetryAfter(header, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLimiterRefillsTokens(t *testing.T) {
+	limiter := NewLimiter(time.Second, 2)
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	// The bucket starts full.
+	for i := range 2 {
+		if delay := limiter.reserve(now); delay != 0 {
+			t.Fatalf("token %d: got a wait of %v, want none", i+1, delay)
+		}
+	}
+	if delay := limiter.reserve(now); delay != time.Second {
+		t.Errorf("empty bucket: got a wait of %v, want 1s", delay)
+	}
+	if delay := limiter.reserve(now.Add(500 * time.Millisecond)); delay != 500*time.Millisecond {
+		t.Errorf("half a token: got a wait of %v, want 500ms", delay)
+	}
+	if delay := limiter.reserve(now.Add(time.Second)); delay != 0 {
+		t.Errorf("refilled token: got a wait of %v, want none", delay)
+	}
+	// The bucket never holds more than its burst.
+	later := now.Add(time.Hour)
+	for i := range 2 {
+		if delay := limiter.reserve(later); delay != 0 {
+			t.Fatalf("after an hour, token %d: got a wait of %v, want none", i+1, delay)
+		}
+	}
+	if delay := limiter.reserve(later); delay == 0 {
+		t.Errorf("after an hour: got a third token, want the burst of 2")
+	}
+}
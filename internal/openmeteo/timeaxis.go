@@ -0,0 +1,34 @@
+package openmeteo
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeAxis holds the time steps shared by the series of a forecast section,
+// e.g. the start of every hour for hourly data or midnight of every day for
+// daily data. Steps are ascending and located in the forecast's fixed zone.
+type TimeAxis []time.Time
+
+// IndexAt returns the index of the step containing t, that is the last step at
+// or before t. It returns -1 when t precedes the first step.
+func (a TimeAxis) IndexAt(t time.Time) int {
+	return sort.Search(len(a), func(i int) bool {
+		return a[i].After(t)
+	}) - 1
+}
+
+// Between returns the index range [start, end) of the steps at or after from
+// and before to, ready to slice the axis or any series sharing it.
+func (a TimeAxis) Between(from, to time.Time) (int, int) {
+	start := sort.Search(len(a), func(i int) bool {
+		return !a[i].Before(from)
+	})
+	end := sort.Search(len(a), func(i int) bool {
+		return !a[i].Before(to)
+	})
+	if end < start {
+		end = start
+	}
+	return start, end
+}
@@ -0,0 +1,157 @@
+package openmeteo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Temperature units accepted by the temperature_unit parameter.
+type TemperatureUnit string
+
+const (
+	Celsius    TemperatureUnit = "celsius"
+	Fahrenheit TemperatureUnit = "fahrenheit"
+)
+
+// Wind speed units accepted by the wind_speed_unit parameter.
+type WindSpeedUnit string
+
+const (
+	KilometresPerHour WindSpeedUnit = "kmh"
+	MetresPerSecond   WindSpeedUnit = "ms"
+	MilesPerHour      WindSpeedUnit = "mph"
+	Knots             WindSpeedUnit = "kn"
+)
+
+// Precipitation units accepted by the precipitation_unit parameter.
+type PrecipitationUnit string
+
+const (
+	Millimetres PrecipitationUnit = "mm"
+	Inches      PrecipitationUnit = "inch"
+)
+
+// Length units accepted by the length_unit parameter of the Marine API.
+type LengthUnit string
+
+const (
+	MetricLength   LengthUnit = "metric"
+	ImperialLength LengthUnit = "imperial"
+)
+
+// Units selects the unit for each quantity of a forecast. Empty fields are
+// left out of the request so the API default (metric) applies.
+type Units struct {
+	Temperature   TemperatureUnit   `json:"temperature,omitempty"`
+	WindSpeed     WindSpeedUnit     `json:"wind_speed,omitempty"`
+	Precipitation PrecipitationUnit `json:"precipitation,omitempty"`
+}
+
+var (
+	MetricUnits = Units{
+		Temperature:   Celsius,
+		WindSpeed:     KilometresPerHour,
+		Precipitation: Millimetres,
+	}
+	ImperialUnits = Units{
+		Temperature:   Fahrenheit,
+		WindSpeed:     MilesPerHour,
+		Precipitation: Inches,
+	}
+)
+
+// Fill in the API defaults for the quantities left empty.
+func (u Units) normalized() Units {
+	if u.Temperature == "" {
+		u.Temperature = MetricUnits.Temperature
+	}
+	if u.WindSpeed == "" {
+		u.WindSpeed = MetricUnits.WindSpeed
+	}
+	if u.Precipitation == "" {
+		u.Precipitation = MetricUnits.Precipitation
+	}
+	return u
+}
+
+// System names the unit system: "metric", "imperial" or "custom".
+func (u Units) System() string {
+	switch u.normalized() {
+	case MetricUnits:
+		return "metric"
+	case ImperialUnits:
+		return "imperial"
+	default:
+		return "custom"
+	}
+}
+
+// Toggle switches between the metric and imperial systems. Custom units
+// switch to metric.
+func (u Units) Toggle() Units {
+	if u.System() == "metric" {
+		return ImperialUnits
+	}
+	return MetricUnits
+}
+
+// Compose the query parameters selecting the units, starting with "&".
+func writeUnitsQuery(units Units) string {
+	var query string
+	if units.Temperature != "" {
+		query += fmt.Sprintf("&temperature_unit=%s", units.Temperature)
+	}
+	if units.WindSpeed != "" {
+		query += fmt.Sprintf("&wind_speed_unit=%s", units.WindSpeed)
+	}
+	if units.Precipitation != "" {
+		query += fmt.Sprintf("&precipitation_unit=%s", units.Precipitation)
+	}
+	return query
+}
+
+// ParseUnits reads "metric", "imperial" or a custom comma-separated list of
+// quantity=unit pairs, e.g. "temperature=fahrenheit,wind_speed=kn". Custom
+// quantities that are not listed use the metric unit.
+func ParseUnits(value string) (Units, error) {
+	switch value {
+	case "metric":
+		return MetricUnits, nil
+	case "imperial":
+		return ImperialUnits, nil
+	}
+
+	units := MetricUnits
+	for pair := range strings.SplitSeq(value, ",") {
+		quantity, unit, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return Units{}, fmt.Errorf("invalid units %q: expected metric, imperial or quantity=unit pairs", value)
+		}
+		switch quantity {
+		case "temperature":
+			switch TemperatureUnit(unit) {
+			case Celsius, Fahrenheit:
+				units.Temperature = TemperatureUnit(unit)
+			default:
+				return Units{}, fmt.Errorf("invalid temperature unit %q", unit)
+			}
+		case "wind_speed":
+			switch WindSpeedUnit(unit) {
+			case KilometresPerHour, MetresPerSecond, MilesPerHour, Knots:
+				units.WindSpeed = WindSpeedUnit(unit)
+			default:
+				return Units{}, fmt.Errorf("invalid wind speed unit %q", unit)
+			}
+		case "precipitation":
+			switch PrecipitationUnit(unit) {
+			case Millimetres, Inches:
+				units.Precipitation = PrecipitationUnit(unit)
+			default:
+				return Units{}, fmt.Errorf("invalid precipitation unit %q", unit)
+			}
+		default:
+			return Units{}, fmt.Errorf("invalid units quantity %q", quantity)
+		}
+	}
+	return units, nil
+}
@@ -0,0 +1,31 @@
+package openmeteo
+
+// The constants of the variable types below, their catalogs, e.g.
+// CurrentVariableCatalog, and the lookups used to decode responses are
+// generated from variables.txt, one line per variable.
+//go:generate go run ./internal/genvariables
+
+// Variables available to request from the Open-Meteo Forecast V1 API for current weather.
+// Every hourly variable can be requested as a current condition as well, the
+// most useful ones are listed here.
+type CurrentVariables string
+
+// Variables available to request from the Open-Meteo Forecast V1 API for daily weather.
+type DailyVariables string
+
+// Variables available to request from the Open-Meteo Forecast V1 API for hourly weather.
+type HourlyVariables string
+
+// Variables available to request from the Open-Meteo Forecast V1 API in 15-minute steps.
+// Only a few regions have native 15-minute models, elsewhere the values are
+// interpolated from the hourly data.
+type Minutely15Variables string
+
+// Index a variable catalog by the name used in the API payload.
+func newVariableLookup[T ~string](catalog []T) map[string]T {
+	lookup := make(map[string]T, len(catalog))
+	for _, variable := range catalog {
+		lookup[string(variable)] = variable
+	}
+	return lookup
+}
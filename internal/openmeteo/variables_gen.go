@@ -0,0 +1,453 @@
+// Code generated by genvariables from variables.txt; DO NOT EDIT.
+
+package openmeteo
+
+const (
+	CurrentTemperature2m            CurrentVariables = "temperature_2m"
+	CurrentRelativeHumidity2m       CurrentVariables = "relative_humidity_2m"
+	CurrentDewPoint2m               CurrentVariables = "dew_point_2m"
+	CurrentApparentTemperature      CurrentVariables = "apparent_temperature"
+	CurrentIsDay                    CurrentVariables = "is_day"
+	CurrentWeatherCode              CurrentVariables = "weather_code"
+	CurrentCloudCover               CurrentVariables = "cloud_cover"
+	CurrentSeaLevelPressure         CurrentVariables = "pressure_msl"
+	CurrentSurfacePressure          CurrentVariables = "surface_pressure"
+	CurrentPrecipitation            CurrentVariables = "precipitation"
+	CurrentRain                     CurrentVariables = "rain"
+	CurrentShowers                  CurrentVariables = "showers"
+	CurrentSnowfall                 CurrentVariables = "snowfall"
+	CurrentSnowDepth                CurrentVariables = "snow_depth"
+	CurrentVisibility               CurrentVariables = "visibility"
+	CurrentUVIndex                  CurrentVariables = "uv_index"
+	CurrentWindSpeed10m             CurrentVariables = "wind_speed_10m"
+	CurrentWindDirection10m         CurrentVariables = "wind_direction_10m"
+	CurrentWindGusts10m             CurrentVariables = "wind_gusts_10m"
+	CurrentShortwaveRadiation       CurrentVariables = "shortwave_radiation"
+	CurrentCAPE                     CurrentVariables = "cape"
+	CurrentFreezingLevelHeight      CurrentVariables = "freezing_level_height"
+	CurrentSunshineDuration         CurrentVariables = "sunshine_duration"
+	CurrentPrecipitationProbability CurrentVariables = "precipitation_probability"
+)
+
+// CurrentVariableCatalog lists every CurrentVariables value, in the order of variables.txt.
+var CurrentVariableCatalog = []CurrentVariables{
+	CurrentTemperature2m,
+	CurrentRelativeHumidity2m,
+	CurrentDewPoint2m,
+	CurrentApparentTemperature,
+	CurrentIsDay,
+	CurrentWeatherCode,
+	CurrentCloudCover,
+	CurrentSeaLevelPressure,
+	CurrentSurfacePressure,
+	CurrentPrecipitation,
+	CurrentRain,
+	CurrentShowers,
+	CurrentSnowfall,
+	CurrentSnowDepth,
+	CurrentVisibility,
+	CurrentUVIndex,
+	CurrentWindSpeed10m,
+	CurrentWindDirection10m,
+	CurrentWindGusts10m,
+	CurrentShortwaveRadiation,
+	CurrentCAPE,
+	CurrentFreezingLevelHeight,
+	CurrentSunshineDuration,
+	CurrentPrecipitationProbability,
+}
+
+var currentVariableLookup = newVariableLookup(CurrentVariableCatalog)
+
+const (
+	DailyWeatherCode                  DailyVariables = "weathercode"
+	DailyTemperature2mMax             DailyVariables = "temperature_2m_max"
+	DailyTemperature2mMin             DailyVariables = "temperature_2m_min"
+	DailyTemperature2mMean            DailyVariables = "temperature_2m_mean"
+	DailyApparentTemperatureMax       DailyVariables = "apparent_temperature_max"
+	DailyApparentTemperatureMin       DailyVariables = "apparent_temperature_min"
+	DailyApparentTemperatureMean      DailyVariables = "apparent_temperature_mean"
+	DailySunrise                      DailyVariables = "sunrise"
+	DailySunset                       DailyVariables = "sunset"
+	DailyDaylightDuration             DailyVariables = "daylight_duration"
+	DailySunshineDuration             DailyVariables = "sunshine_duration"
+	DailyUVIndexMax                   DailyVariables = "uv_index_max"
+	DailyUVIndexClearSkyMax           DailyVariables = "uv_index_clear_sky_max"
+	DailyPrecipitationSum             DailyVariables = "precipitation_sum"
+	DailyRainSum                      DailyVariables = "rain_sum"
+	DailyShowersSum                   DailyVariables = "showers_sum"
+	DailySnowfallSum                  DailyVariables = "snowfall_sum"
+	DailyPrecipitationHours           DailyVariables = "precipitation_hours"
+	DailyPrecipitationProbabilityMax  DailyVariables = "precipitation_probability_max"
+	DailyPrecipitationProbabilityMin  DailyVariables = "precipitation_probability_min"
+	DailyPrecipitationProbabilityMean DailyVariables = "precipitation_probability_mean"
+	DailyWindSpeed10mMax              DailyVariables = "wind_speed_10m_max"
+	DailyWindGusts10mMax              DailyVariables = "wind_gusts_10m_max"
+	DailyWindDirection10mDominant     DailyVariables = "wind_direction_10m_dominant"
+	DailyShortwaveRadiationSum        DailyVariables = "shortwave_radiation_sum"
+	DailyET0FAOEvapotranspiration     DailyVariables = "et0_fao_evapotranspiration"
+	DailyDewPoint2mMean               DailyVariables = "dew_point_2m_mean"
+	DailyRelativeHumidity2mMean       DailyVariables = "relative_humidity_2m_mean"
+	DailyCloudCoverMean               DailyVariables = "cloud_cover_mean"
+	DailySeaLevelPressureMean         DailyVariables = "pressure_msl_mean"
+	DailyVisibilityMean               DailyVariables = "visibility_mean"
+	DailyCAPEMax                      DailyVariables = "cape_max"
+)
+
+// DailyVariableCatalog lists every DailyVariables value, in the order of variables.txt.
+var DailyVariableCatalog = []DailyVariables{
+	DailyWeatherCode,
+	DailyTemperature2mMax,
+	DailyTemperature2mMin,
+	DailyTemperature2mMean,
+	DailyApparentTemperatureMax,
+	DailyApparentTemperatureMin,
+	DailyApparentTemperatureMean,
+	DailySunrise,
+	DailySunset,
+	DailyDaylightDuration,
+	DailySunshineDuration,
+	DailyUVIndexMax,
+	DailyUVIndexClearSkyMax,
+	DailyPrecipitationSum,
+	DailyRainSum,
+	DailyShowersSum,
+	DailySnowfallSum,
+	DailyPrecipitationHours,
+	DailyPrecipitationProbabilityMax,
+	DailyPrecipitationProbabilityMin,
+	DailyPrecipitationProbabilityMean,
+	DailyWindSpeed10mMax,
+	DailyWindGusts10mMax,
+	DailyWindDirection10mDominant,
+	DailyShortwaveRadiationSum,
+	DailyET0FAOEvapotranspiration,
+	DailyDewPoint2mMean,
+	DailyRelativeHumidity2mMean,
+	DailyCloudCoverMean,
+	DailySeaLevelPressureMean,
+	DailyVisibilityMean,
+	DailyCAPEMax,
+}
+
+var dailyVariableLookup = newVariableLookup(DailyVariableCatalog)
+
+const (
+	HourlyTemperature2m               HourlyVariables = "temperature_2m"
+	HourlyRelativeHumidity2m          HourlyVariables = "relative_humidity_2m"
+	HourlyDewPoint2m                  HourlyVariables = "dew_point_2m"
+	HourlyApparentTemperature         HourlyVariables = "apparent_temperature"
+	HourlyPrecipitationProbability    HourlyVariables = "precipitation_probability"
+	HourlyPrecipitation               HourlyVariables = "precipitation"
+	HourlyRain                        HourlyVariables = "rain"
+	HourlyShowers                     HourlyVariables = "showers"
+	HourlySnowfall                    HourlyVariables = "snowfall"
+	HourlySnowDepth                   HourlyVariables = "snow_depth"
+	HourlyWeatherCode                 HourlyVariables = "weathercode"
+	HourlySeaLevelPressure            HourlyVariables = "pressure_msl"
+	HourlySurfacePressure             HourlyVariables = "surface_pressure"
+	HourlyCloudCover                  HourlyVariables = "cloud_cover"
+	HourlyCloudCoverLow               HourlyVariables = "cloud_cover_low"
+	HourlyCloudCoverMid               HourlyVariables = "cloud_cover_mid"
+	HourlyCloudCoverHigh              HourlyVariables = "cloud_cover_high"
+	HourlyVisibility                  HourlyVariables = "visibility"
+	HourlyEvapotranspiration          HourlyVariables = "evapotranspiration"
+	HourlyET0FAOEvapotranspiration    HourlyVariables = "et0_fao_evapotranspiration"
+	HourlyVapourPressureDeficit       HourlyVariables = "vapour_pressure_deficit"
+	HourlyWindSpeed10m                HourlyVariables = "wind_speed_10m"
+	HourlyWindSpeed80m                HourlyVariables = "wind_speed_80m"
+	HourlyWindSpeed120m               HourlyVariables = "wind_speed_120m"
+	HourlyWindSpeed180m               HourlyVariables = "wind_speed_180m"
+	HourlyWindDirection10m            HourlyVariables = "wind_direction_10m"
+	HourlyWindDirection80m            HourlyVariables = "wind_direction_80m"
+	HourlyWindDirection120m           HourlyVariables = "wind_direction_120m"
+	HourlyWindDirection180m           HourlyVariables = "wind_direction_180m"
+	HourlyWindGusts10m                HourlyVariables = "wind_gusts_10m"
+	HourlyTemperature80m              HourlyVariables = "temperature_80m"
+	HourlyTemperature120m             HourlyVariables = "temperature_120m"
+	HourlyTemperature180m             HourlyVariables = "temperature_180m"
+	HourlySoilTemperature0cm          HourlyVariables = "soil_temperature_0cm"
+	HourlySoilTemperature6cm          HourlyVariables = "soil_temperature_6cm"
+	HourlySoilTemperature18cm         HourlyVariables = "soil_temperature_18cm"
+	HourlySoilTemperature54cm         HourlyVariables = "soil_temperature_54cm"
+	HourlySoilMoisture0To1cm          HourlyVariables = "soil_moisture_0_to_1cm"
+	HourlySoilMoisture1To3cm          HourlyVariables = "soil_moisture_1_to_3cm"
+	HourlySoilMoisture3To9cm          HourlyVariables = "soil_moisture_3_to_9cm"
+	HourlySoilMoisture9To27cm         HourlyVariables = "soil_moisture_9_to_27cm"
+	HourlySoilMoisture27To81cm        HourlyVariables = "soil_moisture_27_to_81cm"
+	HourlyUVIndex                     HourlyVariables = "uv_index"
+	HourlyUVIndexClearSky             HourlyVariables = "uv_index_clear_sky"
+	HourlyIsDay                       HourlyVariables = "is_day"
+	HourlySunshineDuration            HourlyVariables = "sunshine_duration"
+	HourlyWetBulbTemperature2m        HourlyVariables = "wet_bulb_temperature_2m"
+	HourlyCAPE                        HourlyVariables = "cape"
+	HourlyLiftedIndex                 HourlyVariables = "lifted_index"
+	HourlyConvectiveInhibition        HourlyVariables = "convective_inhibition"
+	HourlyFreezingLevelHeight         HourlyVariables = "freezing_level_height"
+	HourlyBoundaryLayerHeight         HourlyVariables = "boundary_layer_height"
+	HourlyShortwaveRadiation          HourlyVariables = "shortwave_radiation"
+	HourlyDirectRadiation             HourlyVariables = "direct_radiation"
+	HourlyDiffuseRadiation            HourlyVariables = "diffuse_radiation"
+	HourlyDirectNormalIrradiance      HourlyVariables = "direct_normal_irradiance"
+	HourlyGlobalTiltedIrradiance      HourlyVariables = "global_tilted_irradiance"
+	HourlyTerrestrialRadiation        HourlyVariables = "terrestrial_radiation"
+	HourlyShortwaveRadiationInstant   HourlyVariables = "shortwave_radiation_instant"
+	HourlyDirectRadiationInstant      HourlyVariables = "direct_radiation_instant"
+	HourlyDiffuseRadiationInstant     HourlyVariables = "diffuse_radiation_instant"
+	HourlyTerrestrialRadiationInstant HourlyVariables = "terrestrial_radiation_instant"
+)
+
+// HourlyVariableCatalog lists every HourlyVariables value, in the order of variables.txt.
+var HourlyVariableCatalog = []HourlyVariables{
+	HourlyTemperature2m,
+	HourlyRelativeHumidity2m,
+	HourlyDewPoint2m,
+	HourlyApparentTemperature,
+	HourlyPrecipitationProbability,
+	HourlyPrecipitation,
+	HourlyRain,
+	HourlyShowers,
+	HourlySnowfall,
+	HourlySnowDepth,
+	HourlyWeatherCode,
+	HourlySeaLevelPressure,
+	HourlySurfacePressure,
+	HourlyCloudCover,
+	HourlyCloudCoverLow,
+	HourlyCloudCoverMid,
+	HourlyCloudCoverHigh,
+	HourlyVisibility,
+	HourlyEvapotranspiration,
+	HourlyET0FAOEvapotranspiration,
+	HourlyVapourPressureDeficit,
+	HourlyWindSpeed10m,
+	HourlyWindSpeed80m,
+	HourlyWindSpeed120m,
+	HourlyWindSpeed180m,
+	HourlyWindDirection10m,
+	HourlyWindDirection80m,
+	HourlyWindDirection120m,
+	HourlyWindDirection180m,
+	HourlyWindGusts10m,
+	HourlyTemperature80m,
+	HourlyTemperature120m,
+	HourlyTemperature180m,
+	HourlySoilTemperature0cm,
+	HourlySoilTemperature6cm,
+	HourlySoilTemperature18cm,
+	HourlySoilTemperature54cm,
+	HourlySoilMoisture0To1cm,
+	HourlySoilMoisture1To3cm,
+	HourlySoilMoisture3To9cm,
+	HourlySoilMoisture9To27cm,
+	HourlySoilMoisture27To81cm,
+	HourlyUVIndex,
+	HourlyUVIndexClearSky,
+	HourlyIsDay,
+	HourlySunshineDuration,
+	HourlyWetBulbTemperature2m,
+	HourlyCAPE,
+	HourlyLiftedIndex,
+	HourlyConvectiveInhibition,
+	HourlyFreezingLevelHeight,
+	HourlyBoundaryLayerHeight,
+	HourlyShortwaveRadiation,
+	HourlyDirectRadiation,
+	HourlyDiffuseRadiation,
+	HourlyDirectNormalIrradiance,
+	HourlyGlobalTiltedIrradiance,
+	HourlyTerrestrialRadiation,
+	HourlyShortwaveRadiationInstant,
+	HourlyDirectRadiationInstant,
+	HourlyDiffuseRadiationInstant,
+	HourlyTerrestrialRadiationInstant,
+}
+
+var hourlyVariableLookup = newVariableLookup(HourlyVariableCatalog)
+
+const (
+	Minutely15Temperature2m          Minutely15Variables = "temperature_2m"
+	Minutely15RelativeHumidity2m     Minutely15Variables = "relative_humidity_2m"
+	Minutely15DewPoint2m             Minutely15Variables = "dew_point_2m"
+	Minutely15ApparentTemperature    Minutely15Variables = "apparent_temperature"
+	Minutely15Precipitation          Minutely15Variables = "precipitation"
+	Minutely15Rain                   Minutely15Variables = "rain"
+	Minutely15Showers                Minutely15Variables = "showers"
+	Minutely15Snowfall               Minutely15Variables = "snowfall"
+	Minutely15SnowfallHeight         Minutely15Variables = "snowfall_height"
+	Minutely15FreezingLevelHeight    Minutely15Variables = "freezing_level_height"
+	Minutely15WeatherCode            Minutely15Variables = "weather_code"
+	Minutely15WindSpeed10m           Minutely15Variables = "wind_speed_10m"
+	Minutely15WindSpeed80m           Minutely15Variables = "wind_speed_80m"
+	Minutely15WindDirection10m       Minutely15Variables = "wind_direction_10m"
+	Minutely15WindDirection80m       Minutely15Variables = "wind_direction_80m"
+	Minutely15WindGusts10m           Minutely15Variables = "wind_gusts_10m"
+	Minutely15Visibility             Minutely15Variables = "visibility"
+	Minutely15CAPE                   Minutely15Variables = "cape"
+	Minutely15LightningPotential     Minutely15Variables = "lightning_potential"
+	Minutely15IsDay                  Minutely15Variables = "is_day"
+	Minutely15SunshineDuration       Minutely15Variables = "sunshine_duration"
+	Minutely15ShortwaveRadiation     Minutely15Variables = "shortwave_radiation"
+	Minutely15DirectRadiation        Minutely15Variables = "direct_radiation"
+	Minutely15DiffuseRadiation       Minutely15Variables = "diffuse_radiation"
+	Minutely15DirectNormalIrradiance Minutely15Variables = "direct_normal_irradiance"
+)
+
+// Minutely15VariableCatalog lists every Minutely15Variables value, in the order of variables.txt.
+var Minutely15VariableCatalog = []Minutely15Variables{
+	Minutely15Temperature2m,
+	Minutely15RelativeHumidity2m,
+	Minutely15DewPoint2m,
+	Minutely15ApparentTemperature,
+	Minutely15Precipitation,
+	Minutely15Rain,
+	Minutely15Showers,
+	Minutely15Snowfall,
+	Minutely15SnowfallHeight,
+	Minutely15FreezingLevelHeight,
+	Minutely15WeatherCode,
+	Minutely15WindSpeed10m,
+	Minutely15WindSpeed80m,
+	Minutely15WindDirection10m,
+	Minutely15WindDirection80m,
+	Minutely15WindGusts10m,
+	Minutely15Visibility,
+	Minutely15CAPE,
+	Minutely15LightningPotential,
+	Minutely15IsDay,
+	Minutely15SunshineDuration,
+	Minutely15ShortwaveRadiation,
+	Minutely15DirectRadiation,
+	Minutely15DiffuseRadiation,
+	Minutely15DirectNormalIrradiance,
+}
+
+var minutely15VariableLookup = newVariableLookup(Minutely15VariableCatalog)
+
+const (
+	AirQualityEuropeanAQI     AirQualityVariables = "european_aqi"
+	AirQualityUSAQI           AirQualityVariables = "us_aqi"
+	AirQualityPM10            AirQualityVariables = "pm10"
+	AirQualityPM2_5           AirQualityVariables = "pm2_5"
+	AirQualityCarbonMonoxide  AirQualityVariables = "carbon_monoxide"
+	AirQualityNitrogenDioxide AirQualityVariables = "nitrogen_dioxide"
+	AirQualitySulphurDioxide  AirQualityVariables = "sulphur_dioxide"
+	AirQualityOzone           AirQualityVariables = "ozone"
+	AirQualityDust            AirQualityVariables = "dust"
+	AirQualityUVIndex         AirQualityVariables = "uv_index"
+	AirQualityAlderPollen     AirQualityVariables = "alder_pollen"
+	AirQualityBirchPollen     AirQualityVariables = "birch_pollen"
+	AirQualityGrassPollen     AirQualityVariables = "grass_pollen"
+	AirQualityMugwortPollen   AirQualityVariables = "mugwort_pollen"
+	AirQualityOlivePollen     AirQualityVariables = "olive_pollen"
+	AirQualityRagweedPollen   AirQualityVariables = "ragweed_pollen"
+)
+
+// AirQualityVariableCatalog lists every AirQualityVariables value, in the order of variables.txt.
+var AirQualityVariableCatalog = []AirQualityVariables{
+	AirQualityEuropeanAQI,
+	AirQualityUSAQI,
+	AirQualityPM10,
+	AirQualityPM2_5,
+	AirQualityCarbonMonoxide,
+	AirQualityNitrogenDioxide,
+	AirQualitySulphurDioxide,
+	AirQualityOzone,
+	AirQualityDust,
+	AirQualityUVIndex,
+	AirQualityAlderPollen,
+	AirQualityBirchPollen,
+	AirQualityGrassPollen,
+	AirQualityMugwortPollen,
+	AirQualityOlivePollen,
+	AirQualityRagweedPollen,
+}
+
+var airQualityVariableLookup = newVariableLookup(AirQualityVariableCatalog)
+
+const (
+	MarineWaveHeight            MarineVariables = "wave_height"
+	MarineWaveDirection         MarineVariables = "wave_direction"
+	MarineWavePeriod            MarineVariables = "wave_period"
+	MarineWindWaveHeight        MarineVariables = "wind_wave_height"
+	MarineWindWaveDirection     MarineVariables = "wind_wave_direction"
+	MarineWindWavePeriod        MarineVariables = "wind_wave_period"
+	MarineSwellWaveHeight       MarineVariables = "swell_wave_height"
+	MarineSwellWaveDirection    MarineVariables = "swell_wave_direction"
+	MarineSwellWavePeriod       MarineVariables = "swell_wave_period"
+	MarineSeaSurfaceTemperature MarineVariables = "sea_surface_temperature"
+	MarineOceanCurrentVelocity  MarineVariables = "ocean_current_velocity"
+	MarineOceanCurrentDirection MarineVariables = "ocean_current_direction"
+	MarineSeaLevelHeight        MarineVariables = "sea_level_height_msl"
+)
+
+// MarineVariableCatalog lists every MarineVariables value, in the order of variables.txt.
+var MarineVariableCatalog = []MarineVariables{
+	MarineWaveHeight,
+	MarineWaveDirection,
+	MarineWavePeriod,
+	MarineWindWaveHeight,
+	MarineWindWaveDirection,
+	MarineWindWavePeriod,
+	MarineSwellWaveHeight,
+	MarineSwellWaveDirection,
+	MarineSwellWavePeriod,
+	MarineSeaSurfaceTemperature,
+	MarineOceanCurrentVelocity,
+	MarineOceanCurrentDirection,
+	MarineSeaLevelHeight,
+}
+
+var marineVariableLookup = newVariableLookup(MarineVariableCatalog)
+
+const (
+	MarineDailyWaveHeightMax              MarineDailyVariables = "wave_height_max"
+	MarineDailyWaveDirectionDominant      MarineDailyVariables = "wave_direction_dominant"
+	MarineDailyWavePeriodMax              MarineDailyVariables = "wave_period_max"
+	MarineDailySwellWaveHeightMax         MarineDailyVariables = "swell_wave_height_max"
+	MarineDailySwellWaveDirectionDominant MarineDailyVariables = "swell_wave_direction_dominant"
+	MarineDailySwellWavePeriodMax         MarineDailyVariables = "swell_wave_period_max"
+	MarineDailyWindWaveHeightMax          MarineDailyVariables = "wind_wave_height_max"
+	MarineDailyWindWaveDirectionDominant  MarineDailyVariables = "wind_wave_direction_dominant"
+	MarineDailyWindWavePeriodMax          MarineDailyVariables = "wind_wave_period_max"
+	MarineDailySeaSurfaceTemperatureMax   MarineDailyVariables = "sea_surface_temperature_max"
+)
+
+// MarineDailyVariableCatalog lists every MarineDailyVariables value, in the order of variables.txt.
+var MarineDailyVariableCatalog = []MarineDailyVariables{
+	MarineDailyWaveHeightMax,
+	MarineDailyWaveDirectionDominant,
+	MarineDailyWavePeriodMax,
+	MarineDailySwellWaveHeightMax,
+	MarineDailySwellWaveDirectionDominant,
+	MarineDailySwellWavePeriodMax,
+	MarineDailyWindWaveHeightMax,
+	MarineDailyWindWaveDirectionDominant,
+	MarineDailyWindWavePeriodMax,
+	MarineDailySeaSurfaceTemperatureMax,
+}
+
+var marineDailyVariableLookup = newVariableLookup(MarineDailyVariableCatalog)
+
+const (
+	FloodRiverDischarge       FloodVariables = "river_discharge"
+	FloodRiverDischargeMean   FloodVariables = "river_discharge_mean"
+	FloodRiverDischargeMedian FloodVariables = "river_discharge_median"
+	FloodRiverDischargeMax    FloodVariables = "river_discharge_max"
+	FloodRiverDischargeMin    FloodVariables = "river_discharge_min"
+	FloodRiverDischargeP25    FloodVariables = "river_discharge_p25"
+	FloodRiverDischargeP75    FloodVariables = "river_discharge_p75"
+)
+
+// FloodVariableCatalog lists every FloodVariables value, in the order of variables.txt.
+var FloodVariableCatalog = []FloodVariables{
+	FloodRiverDischarge,
+	FloodRiverDischargeMean,
+	FloodRiverDischargeMedian,
+	FloodRiverDischargeMax,
+	FloodRiverDischargeMin,
+	FloodRiverDischargeP25,
+	FloodRiverDischargeP75,
+}
+
+var floodVariableLookup = newVariableLookup(FloodVariableCatalog)
@@ -0,0 +1,134 @@
+package openmeteo
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// A catalog and its lookup, with the variables as plain strings.
+type variableSet struct {
+	catalog []string
+	lookup  map[string]string
+}
+
+func newVariableSet[T ~string](catalog []T, lookup map[string]T) variableSet {
+	set := variableSet{lookup: make(map[string]string, len(lookup))}
+	for _, variable := range catalog {
+		set.catalog = append(set.catalog, string(variable))
+	}
+	for key, variable := range lookup {
+		set.lookup[key] = string(variable)
+	}
+	return set
+}
+
+// Every variable type of the package, by type name.
+var variableSets = map[string]variableSet{
+	"CurrentVariables":     newVariableSet(CurrentVariableCatalog, currentVariableLookup),
+	"DailyVariables":       newVariableSet(DailyVariableCatalog, dailyVariableLookup),
+	"HourlyVariables":      newVariableSet(HourlyVariableCatalog, hourlyVariableLookup),
+	"Minutely15Variables":  newVariableSet(Minutely15VariableCatalog, minutely15VariableLookup),
+	"AirQualityVariables":  newVariableSet(AirQualityVariableCatalog, airQualityVariableLookup),
+	"MarineVariables":      newVariableSet(MarineVariableCatalog, marineVariableLookup),
+	"MarineDailyVariables": newVariableSet(MarineDailyVariableCatalog, marineDailyVariableLookup),
+	"FloodVariables":       newVariableSet(FloodVariableCatalog, floodVariableLookup),
+}
+
+// The string constants declared in the package sources, by type name.
+func declaredVariables(t *testing.T) (map[string][]string, map[string]bool) {
+	t.Helper()
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	consts := make(map[string][]string)
+	types := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, entry.Name(), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if strings.HasSuffix(spec.Name.Name, "Variables") {
+						types[spec.Name.Name] = true
+					}
+				case *ast.ValueSpec:
+					ident, ok := spec.Type.(*ast.Ident)
+					if gen.Tok != token.CONST || !ok || !strings.HasSuffix(ident.Name, "Variables") {
+						continue
+					}
+					for _, value := range spec.Values {
+						if literal, ok := value.(*ast.BasicLit); ok {
+							name, _ := strconv.Unquote(literal.Value)
+							consts[ident.Name] = append(consts[ident.Name], name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return consts, types
+}
+
+func TestVariableConstantsAreCataloged(t *testing.T) {
+	consts, types := declaredVariables(t)
+	for typeName := range types {
+		if _, ok := variableSets[typeName]; !ok {
+			t.Errorf("%s has no catalog in variableSets", typeName)
+		}
+	}
+	for typeName, names := range consts {
+		set := variableSets[typeName]
+		for _, name := range names {
+			if !slices.Contains(set.catalog, name) {
+				t.Errorf("%s %q is missing from its catalog", typeName, name)
+			}
+			if _, ok := set.lookup[name]; !ok {
+				t.Errorf("%s %q is missing from its lookup", typeName, name)
+			}
+		}
+	}
+}
+
+func TestVariableCatalogsHaveNoDuplicates(t *testing.T) {
+	for typeName, set := range variableSets {
+		seen := make(map[string]bool)
+		for _, name := range set.catalog {
+			if seen[name] {
+				t.Errorf("%s %q is listed twice", typeName, name)
+			}
+			seen[name] = true
+		}
+	}
+}
+
+func TestVariableLookupsRoundTrip(t *testing.T) {
+	for typeName, set := range variableSets {
+		if len(set.lookup) != len(set.catalog) {
+			t.Errorf("%s: lookup has %d keys for %d variables", typeName, len(set.lookup), len(set.catalog))
+		}
+		for key, variable := range set.lookup {
+			if key != variable {
+				t.Errorf("%s: lookup key %q maps to %q", typeName, key, variable)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+)
+
+const CLIMATE_NORMALS_DIR = "normals"
+const DISCHARGE_BAND_DIR = "discharge"
+const RESPONSES_DIR = "responses"
+
+func getCacheDir() (string, error) {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir := filepath.Join(userCacheDir, "clima")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+
+	return cacheDir, nil
+}
+
+// ResponseCacheDir is the directory of the API responses cache, see
+// openmeteo.ResponseCache.
+func ResponseCacheDir() (string, error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	responsesDir := filepath.Join(cacheDir, RESPONSES_DIR)
+	if err := os.MkdirAll(responsesDir, 0755); err != nil {
+		return "", err
+	}
+
+	return responsesDir, nil
+}
+
+// Locations from the geocoding API are keyed by ID, those resolved offline by
+// their coordinates.
+func locationKey(location openmeteo.GeocodingResult) string {
+	if location.ID != 0 {
+		return fmt.Sprintf("%d", location.ID)
+	}
+	return fmt.Sprintf("%.4f_%.4f", location.Latitude, location.Longitude)
+}
+
+// The path of the cache file of a location under dir.
+func getLocationCachePath(dir string, location openmeteo.GeocodingResult) (string, error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	locationDir := filepath.Join(cacheDir, dir)
+	if err := os.MkdirAll(locationDir, 0755); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(locationDir, locationKey(location)+".json"), nil
+}
+
+// LoadClimateNormals reads the cached normals of a location. The normals
+// don't change, so the cache never expires. Reports false when not cached.
+func LoadClimateNormals(location openmeteo.GeocodingResult) (openmeteo.ClimateNormals, bool, error) {
+	path, err := getLocationCachePath(CLIMATE_NORMALS_DIR, location)
+	if err != nil {
+		return openmeteo.ClimateNormals{}, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return openmeteo.ClimateNormals{}, false, nil
+		}
+		return openmeteo.ClimateNormals{}, false, err
+	}
+
+	var normals openmeteo.ClimateNormals
+	if err := json.Unmarshal(data, &normals); err != nil {
+		return openmeteo.ClimateNormals{}, false, err
+	}
+
+	return normals, true, nil
+}
+
+func SaveClimateNormals(location openmeteo.GeocodingResult, normals openmeteo.ClimateNormals) error {
+	path, err := getLocationCachePath(CLIMATE_NORMALS_DIR, location)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(normals)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadDischargeBand reads the cached discharge band of a location. The band
+// covers a past period, so the cache never expires. Reports false when not
+// cached.
+func LoadDischargeBand(location openmeteo.GeocodingResult) (openmeteo.DischargeBand, bool, error) {
+	path, err := getLocationCachePath(DISCHARGE_BAND_DIR, location)
+	if err != nil {
+		return openmeteo.DischargeBand{}, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return openmeteo.DischargeBand{}, false, nil
+		}
+		return openmeteo.DischargeBand{}, false, err
+	}
+
+	var band openmeteo.DischargeBand
+	if err := json.Unmarshal(data, &band); err != nil {
+		return openmeteo.DischargeBand{}, false, err
+	}
+
+	return band, true, nil
+}
+
+func SaveDischargeBand(location openmeteo.GeocodingResult, band openmeteo.DischargeBand) error {
+	path, err := getLocationCachePath(DISCHARGE_BAND_DIR, location)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(band)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
 )
@@ -11,7 +12,11 @@ import (
 const RECENT_LOCATIONS_FILE = "clima_recent.json"
 const MAX_RECENT_LOCATIONS = 5
 
-func getRecentPath() (string, error) {
+// Stored locations older than this are refreshed from their geocoding ID, to
+// pick up renamed areas and fields added to GeocodingResult since they were saved.
+const RECENT_REFRESH_AGE = 30 * 24 * time.Hour
+
+func getConfigDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -22,6 +27,15 @@ func getRecentPath() (string, error) {
 		return "", err
 	}
 
+	return configDir, nil
+}
+
+func getRecentPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
 	return filepath.Join(configDir, RECENT_LOCATIONS_FILE), nil
 }
 
@@ -67,9 +81,12 @@ func AddRecentLocation(location openmeteo.GeocodingResult) error {
 		return err
 	}
 
-	// Remove if already exists
+	// Remove if already exists, keeping the elevation set by the user
 	for i, loc := range locations {
-		if loc.ID == location.ID {
+		if sameLocation(loc, location) {
+			if location.ElevationOverride == nil {
+				location.ElevationOverride = loc.ElevationOverride
+			}
 			locations = append(locations[:i], locations[i+1:]...)
 			break
 		}
@@ -85,3 +102,75 @@ func AddRecentLocation(location openmeteo.GeocodingResult) error {
 
 	return saveRecent(locations)
 }
+
+// SetElevationOverride sets the elevation in meters the forecast of the stored
+// location is for, or clears it when elevation is nil.
+func SetElevationOverride(location openmeteo.GeocodingResult, elevation *float64) error {
+	locations, err := LoadRecentLocations()
+	if err != nil {
+		return err
+	}
+
+	for i, loc := range locations {
+		if sameLocation(loc, location) {
+			locations[i].ElevationOverride = elevation
+		}
+	}
+
+	return saveRecent(locations)
+}
+
+// Locations from the geocoding API are compared by ID. Those resolved offline
+// have no ID, and are compared by coordinates instead.
+func sameLocation(a, b openmeteo.GeocodingResult) bool {
+	if a.ID == 0 || b.ID == 0 {
+		return a.ID == b.ID && a.Latitude == b.Latitude && a.Longitude == b.Longitude
+	}
+	return a.ID == b.ID
+}
+
+// RecentLocationsStale reports whether the recent locations are due for a
+// refresh, see RECENT_REFRESH_AGE.
+func RecentLocationsStale() (bool, error) {
+	settings, err := LoadSettings()
+	if err != nil {
+		return false, err
+	}
+	return time.Since(settings.RecentRefreshedAt) > RECENT_REFRESH_AGE, nil
+}
+
+// UpdateRecentLocations replaces the stored locations sharing an ID with one
+// of updated, keeping their order and elevation overrides, and records the
+// refresh. Locations stored in the meantime are kept. Returns the stored
+// locations.
+func UpdateRecentLocations(updated []openmeteo.GeocodingResult) ([]openmeteo.GeocodingResult, error) {
+	locations, err := LoadRecentLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	// Locations resolved offline have no ID, and are never replaced.
+	byID := make(map[int]openmeteo.GeocodingResult, len(updated))
+	for _, location := range updated {
+		if location.ID != 0 {
+			byID[location.ID] = location
+		}
+	}
+	for i, location := range locations {
+		if location.ID == 0 {
+			continue
+		}
+		if refreshed, ok := byID[location.ID]; ok {
+			refreshed.ElevationOverride = location.ElevationOverride
+			locations[i] = refreshed
+		}
+	}
+
+	if err := saveRecent(locations); err != nil {
+		return nil, err
+	}
+	err = UpdateSettings(func(settings *Settings) {
+		settings.RecentRefreshedAt = time.Now()
+	})
+	return locations, err
+}
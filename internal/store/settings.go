@@ -0,0 +1,85 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+)
+
+const SETTINGS_FILE = "clima_settings.json"
+
+// Settings are the user preferences persisted next to the recent locations.
+type Settings struct {
+	Units      openmeteo.Units        `json:"units"`
+	HideMarine bool                   `json:"hide_marine,omitempty"`
+	HideFlood  bool                   `json:"hide_flood,omitempty"`
+	Model      openmeteo.WeatherModel `json:"model,omitempty"`
+	// RecentRefreshedAt is when the recent locations were last updated from
+	// the geocoding API, see RECENT_REFRESH_AGE.
+	RecentRefreshedAt time.Time `json:"recent_refreshed_at,omitzero"`
+}
+
+func getSettingsPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, SETTINGS_FILE), nil
+}
+
+func LoadSettings() (Settings, error) {
+	path, err := getSettingsPath()
+	if err != nil {
+		return Settings{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Settings{}, nil
+		}
+		return Settings{}, err
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return Settings{}, err
+	}
+
+	return settings, nil
+}
+
+func SaveSettings(settings Settings) error {
+	path, err := getSettingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// UpdateSettings applies update to the persisted settings, keeping the ones it does not touch.
+func UpdateSettings(update func(*Settings)) error {
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+	update(&settings)
+	return SaveSettings(settings)
+}
+
+// SaveUnits updates the persisted unit selection, keeping the other settings.
+func SaveUnits(units openmeteo.Units) error {
+	return UpdateSettings(func(settings *Settings) {
+		settings.Units = units
+	})
+}
@@ -0,0 +1,24 @@
+// Package format holds the number formatting shared by the screens.
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Thousands formats an integer with thousands separators, e.g. -1,234.
+func Thousands(n int) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String()
+}
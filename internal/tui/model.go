@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/store"
 	"github.com/diegoserranor/clima/internal/tui/recent"
 	"github.com/diegoserranor/clima/internal/tui/search"
 	"github.com/diegoserranor/clima/internal/tui/weather"
@@ -32,6 +33,9 @@ type Model struct {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.route == routeWeather {
+		return m.weather.Init()
+	}
 	return m.recent.Init()
 }
 
@@ -118,11 +122,23 @@ func (m Model) View() string {
 	return content
 }
 
-func InitialModel(sink io.Writer) Model {
+func InitialModel(sink io.Writer, settings store.Settings) Model {
+	client := openmeteo.NewClient()
+	// Without a cache directory the responses are only kept for this run.
+	cacheDir, _ := store.ResponseCacheDir()
+	client.Cache = openmeteo.NewResponseCache(cacheDir)
 	return Model{
 		sink:    sink,
-		recent:  recent.New(),
-		search:  search.New(),
-		weather: weather.New(openmeteo.GeocodingResult{}, sink),
+		recent:  recent.New(client),
+		search:  search.New(client),
+		weather: weather.New(openmeteo.GeocodingResult{}, settings, client, sink),
 	}
 }
+
+// WithLocation starts the program on the weather of location, skipping the
+// recent locations screen.
+func (m Model) WithLocation(location openmeteo.GeocodingResult) Model {
+	m.route = routeWeather
+	m.weather = m.weather.Reset(location)
+	return m
+}
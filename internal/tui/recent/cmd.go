@@ -1,6 +1,8 @@
 package recent
 
 import (
+	"context"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/diegoserranor/clima/internal/openmeteo"
 	"github.com/diegoserranor/clima/internal/store"
@@ -21,6 +23,75 @@ func getRecentLocationsCmd() tea.Cmd {
 	}
 }
 
+// Fetches the current conditions of every location in one request, in the
+// units saved by the weather screen.
+func getCurrentConditionsCmd(client *openmeteo.Client, locations []openmeteo.GeocodingResult) tea.Cmd {
+	return func() tea.Msg {
+		settings, err := store.LoadSettings()
+		if err != nil {
+			return conditionsMsg{err: err}
+		}
+		coordinates := make([]openmeteo.Coordinates, len(locations))
+		for i, location := range locations {
+			coordinates[i] = openmeteo.Coordinates{
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+				Elevation: location.ElevationOverride,
+			}
+		}
+		params := openmeteo.ForecastParams{
+			Timezone: "auto",
+			Units:    settings.Units,
+			Current: []openmeteo.CurrentVariables{
+				openmeteo.CurrentTemperature2m,
+				openmeteo.CurrentWeatherCode,
+			},
+		}
+		forecasts, err := client.GetForecasts(context.Background(), coordinates, params)
+		return conditionsMsg{
+			forecasts: forecasts,
+			err:       err,
+		}
+	}
+}
+
+// Updates the stored locations from their geocoding ID once they are older
+// than store.RECENT_REFRESH_AGE. Produces no message when they are fresh.
+func refreshRecentLocationsCmd(client *openmeteo.Client, locations []openmeteo.GeocodingResult) tea.Cmd {
+	return func() tea.Msg {
+		stale, err := store.RecentLocationsStale()
+		if err != nil || !stale {
+			return nil
+		}
+
+		refreshed := make([]openmeteo.GeocodingResult, 0, len(locations))
+		for _, location := range locations {
+			if location.ID == 0 {
+				continue
+			}
+			// The cached response is what needs refreshing.
+			result, err := client.GetLocationByID(openmeteo.BypassCache(context.Background()), location.ID)
+			if err != nil {
+				// Try again next time when the API is unreachable, skip the
+				// locations it no longer knows.
+				if openmeteo.IsRetryable(err) {
+					return refreshedMsg{err: err}
+				}
+				continue
+			}
+			if result.ID == location.ID {
+				refreshed = append(refreshed, result)
+			}
+		}
+
+		locations, err = store.UpdateRecentLocations(refreshed)
+		return refreshedMsg{
+			locations: locations,
+			err:       err,
+		}
+	}
+}
+
 func pickCmd(location openmeteo.GeocodingResult, ok bool) tea.Cmd {
 	return func() tea.Msg {
 		return RecentCompleteMsg{
@@ -9,6 +9,7 @@ import (
 // Implements list.Item interface and wraps location.Location
 type recentLocationItem struct {
 	openmeteo.GeocodingResult
+	current openmeteo.ForecastResponse
 }
 
 func (i recentLocationItem) FilterValue() string {
@@ -23,6 +24,14 @@ func (i recentLocationItem) Title() string {
 	if i.Country != "" {
 		place = place + ", " + i.Country
 	}
+	if temperature, ok := i.current.CurrentMeasurement(openmeteo.CurrentTemperature2m); ok {
+		place += fmt.Sprintf(" · %.1f %s", temperature.Value, temperature.Unit)
+		if code, ok := i.current.CurrentMeasurement(openmeteo.CurrentWeatherCode); ok {
+			if conditions := openmeteo.MapWeatherCode(code.Value); conditions != "" {
+				place += ", " + conditions
+			}
+		}
+	}
 	return place
 }
 
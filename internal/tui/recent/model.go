@@ -14,7 +14,7 @@ import (
 	"github.com/diegoserranor/clima/internal/tui/theme"
 )
 
-func New() Model {
+func New(client *openmeteo.Client) Model {
 	ellipsis := spinner.New()
 	ellipsis.Spinner = spinner.Ellipsis
 	ellipsis.Style = theme.AccentStyle
@@ -37,6 +37,7 @@ func New() Model {
 	footer := theme.OuterFrameStyle.Render(help)
 
 	return Model{
+		client:      client,
 		windowReady: false,
 		dataReady:   false,
 		ellipsis:    ellipsis,
@@ -48,6 +49,7 @@ func New() Model {
 }
 
 type Model struct {
+	client      *openmeteo.Client
 	windowReady bool
 	dataReady   bool
 	errStr      string
@@ -90,16 +92,56 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if len(msg.locations) == 0 {
 			return m, pickCmd(openmeteo.GeocodingResult{}, false)
 		}
+		refresh := refreshRecentLocationsCmd(m.client, msg.locations)
 		if len(msg.locations) == 1 {
-			return m, pickCmd(msg.locations[0], true)
+			return m, tea.Batch(pickCmd(msg.locations[0], true), refresh)
 		}
 		items := make([]list.Item, len(msg.locations))
 		for i, loc := range msg.locations {
-			items[i] = recentLocationItem{loc}
+			items[i] = recentLocationItem{GeocodingResult: loc}
 		}
 		m.list.SetItems(items)
 		m.dataReady = true
-		return m, nil
+		return m, tea.Batch(getCurrentConditionsCmd(m.client, msg.locations), refresh)
+	case conditionsMsg:
+		// The list works without the conditions, so they are left out on error.
+		items := m.list.Items()
+		if msg.err != nil || len(msg.forecasts) != len(items) {
+			return m, nil
+		}
+		for i, item := range items {
+			if location, ok := item.(recentLocationItem); ok {
+				location.current = msg.forecasts[i]
+				items[i] = location
+			}
+		}
+		cmd := m.list.SetItems(items)
+		return m, cmd
+	case refreshedMsg:
+		if msg.err != nil {
+			return m, nil
+		}
+		// Locations without an ID, resolved offline, were not refreshed and
+		// can't be told apart by it.
+		byID := make(map[int]openmeteo.GeocodingResult, len(msg.locations))
+		for _, location := range msg.locations {
+			if location.ID != 0 {
+				byID[location.ID] = location
+			}
+		}
+		items := m.list.Items()
+		for i, item := range items {
+			location, ok := item.(recentLocationItem)
+			if !ok || location.ID == 0 {
+				continue
+			}
+			if refreshed, ok := byID[location.ID]; ok {
+				location.GeocodingResult = refreshed
+				items[i] = location
+			}
+		}
+		cmd := m.list.SetItems(items)
+		return m, cmd
 	case errorMsg:
 		m.dataReady = true
 		m.errStr = msg.err.Error()
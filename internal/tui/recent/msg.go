@@ -6,6 +6,17 @@ type dataMsg struct {
 	locations []openmeteo.GeocodingResult
 }
 
+// The forecasts are in the order of the listed locations.
+type conditionsMsg struct {
+	forecasts []openmeteo.ForecastResponse
+	err       error
+}
+
+type refreshedMsg struct {
+	locations []openmeteo.GeocodingResult
+	err       error
+}
+
 type errorMsg struct {
 	err error
 }
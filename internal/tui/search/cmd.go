@@ -1,17 +1,45 @@
 package search
 
 import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/diegoserranor/clima/internal/geonames"
 	"github.com/diegoserranor/clima/internal/openmeteo"
 )
 
-func searchLocationsCmd(name string) tea.Cmd {
+// Searches the locations matching query. A trailing two-letter code, as in
+// "Springfield, US", limits the results to that country. When nothing
+// matches in the country, the name is searched everywhere. When the geocoding
+// API can't be reached, the bundled city list is searched instead. Names come
+// in the language of the user's locale.
+func searchLocationsCmd(client *openmeteo.Client, query string) tea.Cmd {
 	return func() tea.Msg {
+		name, countryCode := parseSearchQuery(query)
 		params := openmeteo.GeocodingParams{
-			Name:  name,
-			Count: DEFAULT_SEARCH_COUNT,
+			Name:        name,
+			Count:       DEFAULT_SEARCH_COUNT,
+			Language:    localeLanguage(),
+			CountryCode: countryCode,
+		}
+		res, err := client.SearchLocation(context.Background(), params)
+		if err == nil && len(res.Results) == 0 && countryCode != "" {
+			params.CountryCode = ""
+			res, err = client.SearchLocation(context.Background(), params)
+		}
+		// Only without network, rate limits and server errors keep their own
+		// error and retry hint.
+		if errors.Is(err, openmeteo.ErrNetwork) {
+			if locations := searchOffline(name, countryCode); len(locations) > 0 {
+				return dataMsg{
+					locations: locations,
+					offline:   true,
+				}
+			}
 		}
-		res, err := openmeteo.SearchLocation(params)
 		if err != nil {
 			return errorMsg{
 				err: err,
@@ -23,6 +51,55 @@ func searchLocationsCmd(name string) tea.Cmd {
 	}
 }
 
+// Search the bundled city list, with the same country fallback as the API.
+func searchOffline(name string, countryCode string) []openmeteo.GeocodingResult {
+	cities, err := geonames.Search(name, countryCode, DEFAULT_SEARCH_COUNT)
+	if err == nil && len(cities) == 0 && countryCode != "" {
+		cities, err = geonames.Search(name, "", DEFAULT_SEARCH_COUNT)
+	}
+	if err != nil {
+		return nil
+	}
+	locations := make([]openmeteo.GeocodingResult, len(cities))
+	for i, city := range cities {
+		locations[i] = city.Result()
+	}
+	return locations
+}
+
+// The language of the user's locale, e.g. "de" for LANG=de_DE.UTF-8. Empty,
+// for English, when the locale is not set or is "C" or "POSIX".
+func localeLanguage() string {
+	for _, variable := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(variable)
+		if locale == "" {
+			continue
+		}
+		language, _, _ := strings.Cut(locale, "_")
+		language, _, _ = strings.Cut(language, ".")
+		if len(language) != 2 {
+			return ""
+		}
+		return strings.ToLower(language)
+	}
+	return ""
+}
+
+// Split a "name, CC" query into the name and the country code.
+func parseSearchQuery(query string) (string, string) {
+	name, code, ok := strings.Cut(query, ",")
+	code = strings.TrimSpace(code)
+	if !ok || len(code) != 2 || strings.Contains(code, ",") {
+		return strings.TrimSpace(query), ""
+	}
+	for _, r := range code {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return strings.TrimSpace(query), ""
+		}
+	}
+	return strings.TrimSpace(name), strings.ToUpper(code)
+}
+
 func pickCmd(location openmeteo.GeocodingResult) tea.Cmd {
 	return func() tea.Msg {
 		return SearchCompleteMsg{
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+)
+
+// A client without limiter, cache or retries, searching at serverURL.
+func newTestClient(serverURL string) *openmeteo.Client {
+	client := openmeteo.NewClient()
+	client.GeocodingURL = serverURL + "/v1/search"
+	client.Limiter = nil
+	client.Cache = nil
+	client.Retry = openmeteo.RetryPolicy{MaxAttempts: 1, MaxDelay: time.Millisecond}
+	return client
+}
+
+func TestSearchFallsBackOfflineWithoutNetwork(t *testing.T) {
+	// Nothing listens there anymore, the request fails to connect.
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := newTestClient(server.URL)
+
+	msg := searchLocationsCmd(client, "Springfield, US")()
+	data, ok := msg.(dataMsg)
+	if !ok {
+		t.Fatalf("searchLocationsCmd() = %#v, want offline results", msg)
+	}
+	if !data.offline || len(data.locations) == 0 || data.locations[0].Name != "Springfield" {
+		t.Errorf("searchLocationsCmd() = %+v, want offline Springfields", data)
+	}
+}
+
+func TestSearchKeepsAPIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"invalid parameters", http.StatusBadRequest, openmeteo.ErrInvalidParams},
+		{"rate limited", http.StatusTooManyRequests, openmeteo.ErrRateLimited},
+		{"server error", http.StatusInternalServerError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"error":true,"reason":"nope"}`))
+			}))
+			defer server.Close()
+			client := newTestClient(server.URL)
+
+			msg := searchLocationsCmd(client, "Paris")()
+			errMsg, ok := msg.(errorMsg)
+			if !ok {
+				t.Fatalf("searchLocationsCmd() = %#v, want an error", msg)
+			}
+			var apiErr *openmeteo.APIError
+			if !errors.As(errMsg.err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("searchLocationsCmd() error = %v, want the API error", errMsg.err)
+			}
+			if tt.want != nil && !errors.Is(errMsg.err, tt.want) {
+				t.Errorf("searchLocationsCmd() error = %v, want %v", errMsg.err, tt.want)
+			}
+
+			m, _ := New(client).Update(msg)
+			if m.view != viewError {
+				t.Errorf("view = %d after the error, want viewError", m.view)
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		query       string
+		name        string
+		countryCode string
+	}{
+		{"Springfield, US", "Springfield", "US"},
+		{"springfield,us", "springfield", "US"},
+		{"Springfield", "Springfield", ""},
+		{"Paris, Texas", "Paris, Texas", ""},
+		{"Foo, U1", "Foo, U1", ""},
+		{"  Lyon  ", "Lyon", ""},
+	}
+	for _, tt := range tests {
+		name, countryCode := parseSearchQuery(tt.query)
+		if name != tt.name || countryCode != tt.countryCode {
+			t.Errorf("parseSearchQuery(%q) = %q, %q, want %q, %q", tt.query, name, countryCode, tt.name, tt.countryCode)
+		}
+	}
+}
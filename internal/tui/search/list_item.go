@@ -2,13 +2,16 @@ package search
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/tui/format"
 )
 
 // Implements list.Item interface and wraps openmeteo.GeocodingResult
 type searchListItem struct {
 	openmeteo.GeocodingResult
+	offline bool
 }
 
 func (i searchListItem) FilterValue() string {
@@ -26,6 +29,23 @@ func (i searchListItem) Title() string {
 	return place
 }
 
+// The description tells apart places with the same name: the county, the
+// country code and the population come before the coordinates. Results of
+// the bundled city list are marked offline.
 func (i searchListItem) Description() string {
-	return fmt.Sprintf("Lat: %.4f, Lon: %.4f", i.Latitude, i.Longitude)
+	var parts []string
+	if i.offline {
+		parts = append(parts, "Offline")
+	}
+	if i.Admin2 != "" {
+		parts = append(parts, i.Admin2)
+	}
+	if i.CountryCode != "" {
+		parts = append(parts, i.CountryCode)
+	}
+	if i.Population > 0 {
+		parts = append(parts, "Pop. "+format.Thousands(i.Population))
+	}
+	parts = append(parts, fmt.Sprintf("Lat: %.4f, Lon: %.4f", i.Latitude, i.Longitude))
+	return strings.Join(parts, " · ")
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
 	"github.com/diegoserranor/clima/internal/tui/theme"
 )
 
@@ -18,7 +20,7 @@ const DEFAULT_SEARCH_COUNT = 10
 
 var inputStyle = theme.OuterFrameStyle.PaddingTop(0)
 
-func New() Model {
+func New(client *openmeteo.Client) Model {
 	inputKeys := newInputKeyMap()
 
 	inputHeader := theme.OuterFrameStyle.Render("Location search:")
@@ -31,9 +33,10 @@ func New() Model {
 	ellipsis.Style = theme.AccentStyle
 
 	listDelegate := list.NewDefaultDelegate()
-	listDelegate.ShowDescription = false
 	selectedStyle := list.NewDefaultItemStyles().SelectedTitle
 	listDelegate.Styles.SelectedTitle = selectedStyle.Foreground(theme.AccentColor).BorderForeground(theme.AccentColor)
+	selectedDescStyle := list.NewDefaultItemStyles().SelectedDesc
+	listDelegate.Styles.SelectedDesc = selectedDescStyle.Foreground(theme.SubtleColor).BorderForeground(theme.AccentColor)
 	list := list.New([]list.Item{}, listDelegate, 0, 0)
 	list.SetShowStatusBar(false)
 	list.SetFilteringEnabled(false)
@@ -42,12 +45,13 @@ func New() Model {
 
 	listKeys := newListKeyMap()
 
-	listHeader := theme.OuterFrameStyle.Render("Pick a location:")
+	listHeader := renderListHeader(false)
 
 	listHelp := help.New().View(listKeys)
 	listFooter := theme.OuterFrameStyle.Render(listHelp)
 
 	return Model{
+		client:      client,
 		view:        viewInput,
 		inputKeys:   inputKeys,
 		inputHeader: inputHeader,
@@ -70,8 +74,10 @@ const (
 )
 
 type Model struct {
+	client      *openmeteo.Client
 	windowReady bool
 	view        view
+	err         error
 	input       textinput.Model
 	inputKeys   inputKeyMap
 	inputHeader string
@@ -94,7 +100,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if m.view == viewInput {
 			if key.Matches(msg, m.inputKeys.submit) {
 				m.view = viewLoading
-				return m, tea.Batch(searchLocationsCmd(m.input.Value()), m.ellipsis.Tick)
+				return m, tea.Batch(searchLocationsCmd(m.client, m.input.Value()), m.ellipsis.Tick)
+			}
+			if key.Matches(msg, m.inputKeys.exitSearch) {
+				return m, requestRecentCmd()
+			}
+		}
+		if m.view == viewError {
+			if key.Matches(msg, m.inputKeys.submit) {
+				if openmeteo.IsRetryable(m.err) {
+					m.view = viewLoading
+					return m, tea.Batch(searchLocationsCmd(m.client, m.input.Value()), m.ellipsis.Tick)
+				}
+				m.view = viewInput
+				return m, nil
 			}
 			if key.Matches(msg, m.inputKeys.exitSearch) {
 				return m, requestRecentCmd()
@@ -149,13 +168,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		items := make([]list.Item, len(msg.locations))
 		for i, loc := range msg.locations {
-			items[i] = searchListItem{loc}
+			items[i] = searchListItem{loc, msg.offline}
 		}
 		m.list.SetItems(items)
+		m.listHeader = renderListHeader(msg.offline)
 		m.view = viewPick
 		return m, nil
 	case errorMsg:
 		m.view = viewError
+		m.err = msg.err
 		return m, nil
 	}
 
@@ -190,7 +211,7 @@ func (m Model) View() string {
 		list := theme.OuterFrameStyle.Render(m.list.View())
 		content = fmt.Sprintf("%s%s%s", m.listHeader, list, m.listFooter)
 	case viewError:
-		content = theme.OuterFrameStyle.Render("Error ... try again or quit")
+		content = theme.OuterFrameStyle.Render(renderError(m.err))
 	default:
 		content = theme.OuterFrameStyle.Render("Unknown state (search)")
 	}
@@ -4,6 +4,8 @@ import "github.com/diegoserranor/clima/internal/openmeteo"
 
 type dataMsg struct {
 	locations []openmeteo.GeocodingResult
+	// offline is set when the locations come from the bundled city list.
+	offline bool
 }
 
 type errorMsg struct {
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/tui/theme"
+)
+
+func renderListHeader(offline bool) string {
+	if offline {
+		return theme.OuterFrameStyle.Render("Pick a location (offline, Open-Meteo is unreachable):")
+	}
+	return theme.OuterFrameStyle.Render("Pick a location:")
+}
+
+func renderError(err error) string {
+	var hint string
+	switch {
+	case errors.Is(err, openmeteo.ErrRateLimited):
+		hint = "Open-Meteo is limiting requests. Wait a moment, then press 'enter' to try again."
+	case errors.Is(err, openmeteo.ErrInvalidParams):
+		hint = "The search was rejected. Press 'enter' to edit it."
+	case openmeteo.IsRetryable(err):
+		hint = "Press 'enter' to try again."
+	default:
+		hint = "Press 'enter' to edit the search."
+	}
+	return fmt.Sprintf("Search failed:\n%s\n\n%s Press 'esc' to exit search.", err, hint)
+}
@@ -1,50 +1,321 @@
 package weather
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/diegoserranor/clima/internal/openmeteo"
 	"github.com/diegoserranor/clima/internal/store"
 )
 
-func getForecastCmd(lat float64, long float64) tea.Cmd {
+// Parameters for the forecast shown on the weather screen. The best match
+// model is left to the API. The elevation set by the user for the location
+// takes precedence over the terrain elevation, see getForecastCmd.
+func forecastParams(location openmeteo.GeocodingResult, units openmeteo.Units, model openmeteo.WeatherModel) openmeteo.ForecastParams {
+	var models []openmeteo.WeatherModel
+	if model != "" && model != openmeteo.BestMatch {
+		models = []openmeteo.WeatherModel{model}
+	}
+	return openmeteo.ForecastParams{
+		Latitude:      location.Latitude,
+		Longitude:     location.Longitude,
+		Elevation:     location.ElevationOverride,
+		Timezone:      "auto",
+		ForecastHours: 24, // more than the strip shows, so a cached forecast still fills it offline
+		ForecastDays:  10,
+		// The daily strip shows the past days on demand, they are always
+		// requested so toggling them needs no new request.
+		PastDays: pastDays,
+		// The current step plus the nowcast window and its margin.
+		ForecastMinutely15: int((nowcastWindow+nowcastMargin)/(15*time.Minute)) + 1,
+		Models:             models,
+		Units:              units,
+		Current: []openmeteo.CurrentVariables{
+			openmeteo.CurrentTemperature2m,
+			openmeteo.CurrentApparentTemperature,
+			openmeteo.CurrentRelativeHumidity2m,
+			openmeteo.CurrentIsDay,
+			openmeteo.CurrentWeatherCode,
+			openmeteo.CurrentWindSpeed10m,
+			openmeteo.CurrentWindDirection10m,
+			openmeteo.CurrentWindGusts10m,
+			openmeteo.CurrentPrecipitation,
+			openmeteo.CurrentSeaLevelPressure,
+		},
+		Daily: []openmeteo.DailyVariables{
+			openmeteo.DailyTemperature2mMin,
+			openmeteo.DailyTemperature2mMax,
+			openmeteo.DailyWeatherCode,
+			openmeteo.DailyUVIndexMax,
+			openmeteo.DailySunrise,
+			openmeteo.DailySunset,
+			openmeteo.DailyDaylightDuration,
+		},
+		Hourly: []openmeteo.HourlyVariables{
+			openmeteo.HourlyTemperature2m,
+			openmeteo.HourlyWeatherCode,
+		},
+		Minutely15: []openmeteo.Minutely15Variables{
+			openmeteo.Minutely15Precipitation,
+			openmeteo.Minutely15Snowfall,
+		},
+	}
+}
+
+// Fetches the forecast, looking up the terrain elevation of the location
+// first unless it is already known. The forecast is downscaled to the terrain
+// elevation when params has none. A failed lookup leaves the elevation to the
+// forecast API.
+//
+// Without network, the last forecast cached for the parameters is used
+// instead, whatever its age, and the network error comes along with it.
+func getForecastCmd(ctx context.Context, client *openmeteo.Client, params openmeteo.ForecastParams, terrain *float64) tea.Cmd {
 	return func() tea.Msg {
-		params := openmeteo.ForecastParams{
-			Latitude:      lat,
-			Longitude:     long,
+		msg, err := loadForecast(ctx, client, params, terrain)
+		if errors.Is(err, openmeteo.ErrNetwork) {
+			if cached, cacheErr := loadForecast(openmeteo.FromCache(ctx), client, params, terrain); cacheErr == nil {
+				cached.cached = true
+				cached.err = err
+				cached.ctx = ctx
+				return cached
+			}
+		}
+		if err != nil {
+			return errorMsg{
+				ctx: ctx,
+				err: err,
+			}
+		}
+		msg.ctx = ctx
+		return msg
+	}
+}
+
+// Loads the last forecast cached for the parameters, whatever its age, so the
+// screen can show it right away. Reports a cache miss when there is none.
+func getCachedForecastCmd(ctx context.Context, client *openmeteo.Client, params openmeteo.ForecastParams, terrain *float64) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := loadForecast(openmeteo.FromCache(ctx), client, params, terrain)
+		if err != nil {
+			return cacheMissMsg{ctx: ctx}
+		}
+		msg.cached = true
+		msg.ctx = ctx
+		return msg
+	}
+}
+
+func loadForecast(ctx context.Context, client *openmeteo.Client, params openmeteo.ForecastParams, terrain *float64) (dataMsg, error) {
+	if terrain == nil {
+		location := openmeteo.Coordinates{Latitude: params.Latitude, Longitude: params.Longitude}
+		elevations, err := client.GetElevation(ctx, []openmeteo.Coordinates{location})
+		if err == nil {
+			terrain = &elevations[0]
+		}
+	}
+	if params.Elevation == nil {
+		params.Elevation = terrain
+	}
+	res, err := client.GetForecast(ctx, params)
+	if err != nil {
+		return dataMsg{}, err
+	}
+	return dataMsg{
+		forecast: res,
+		terrain:  terrain,
+	}, nil
+}
+
+// Ticks every minute for the lifetime of ctx, see clockMsg.
+func clockCmd(ctx context.Context) tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg {
+		return clockMsg{ctx: ctx}
+	})
+}
+
+// Fetches the archived weather for the same day last year and the 7 days
+// before today. Today is the current date at the location.
+func getHistoryCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult, units openmeteo.Units, today time.Time) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.ArchiveParams{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+			Timezone:  "auto",
+			Units:     units,
+			Daily: []openmeteo.DailyVariables{
+				openmeteo.DailyTemperature2mMin,
+				openmeteo.DailyTemperature2mMax,
+				openmeteo.DailyWeatherCode,
+			},
+		}
+
+		lastYearParams := params
+		lastYearParams.StartDate = today.AddDate(-1, 0, 0)
+		lastYearParams.EndDate = lastYearParams.StartDate
+		lastYear, err := client.GetArchive(ctx, lastYearParams)
+		if err != nil {
+			return historyMsg{ctx: ctx, err: err}
+		}
+
+		pastWeekParams := params
+		pastWeekParams.StartDate = today.AddDate(0, 0, -7)
+		pastWeekParams.EndDate = today.AddDate(0, 0, -1)
+		pastWeek, err := client.GetArchive(ctx, pastWeekParams)
+		if err != nil {
+			return historyMsg{ctx: ctx, err: err}
+		}
+
+		return historyMsg{
+			ctx:      ctx,
+			lastYear: lastYear,
+			pastWeek: pastWeek,
+		}
+	}
+}
+
+func getAirQualityCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.AirQualityParams{
+			Latitude:      location.Latitude,
+			Longitude:     location.Longitude,
 			Timezone:      "auto",
-			ForecastHours: 10,
-			ForecastDays:  10,
-			Current: []openmeteo.CurrentVariables{
-				openmeteo.CurrentTemperature2m,
-				openmeteo.CurrentApparentTemperature,
-				openmeteo.CurrentRelativeHumidity2m,
-				openmeteo.CurrentIsDay,
-				openmeteo.CurrentWeatherCode,
-				openmeteo.CurrentWindSpeed10m,
-				openmeteo.CurrentWindDirection10m,
-				openmeteo.CurrentWindGusts10m,
-				openmeteo.CurrentPrecipitation,
-				openmeteo.CurrentSeaLevelPressure,
+			ForecastHours: 12,
+			Current: append([]openmeteo.AirQualityVariables{
+				openmeteo.AirQualityEuropeanAQI,
+				openmeteo.AirQualityUSAQI,
+				openmeteo.AirQualityPM2_5,
+				openmeteo.AirQualityPM10,
+				openmeteo.AirQualityOzone,
+			}, openmeteo.PollenVariables...),
+			Hourly: []openmeteo.AirQualityVariables{
+				openmeteo.AirQualityEuropeanAQI,
 			},
+		}
+		res, err := client.GetAirQuality(ctx, params)
+		return airQualityMsg{
+			ctx:        ctx,
+			airQuality: res,
+			err:        err,
+		}
+	}
+}
+
+// How many days before today the daily strip can show.
+const pastDays = 2
+
+// The models compared side by side, all of them are global.
+var comparisonModels = []openmeteo.WeatherModel{
+	openmeteo.ECMWF,
+	openmeteo.GFS,
+	openmeteo.ICON,
+	openmeteo.GEM,
+}
+
+func getComparisonCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult, units openmeteo.Units) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.ForecastParams{
+			Latitude:     location.Latitude,
+			Longitude:    location.Longitude,
+			Timezone:     "auto",
+			ForecastDays: 7,
+			Models:       comparisonModels,
+			Units:        units,
 			Daily: []openmeteo.DailyVariables{
 				openmeteo.DailyTemperature2mMin,
 				openmeteo.DailyTemperature2mMax,
 				openmeteo.DailyWeatherCode,
-				openmeteo.DailyUVIndexMax,
 			},
-			Hourly: []openmeteo.HourlyVariables{
-				openmeteo.HourlyTemperature2m,
-				openmeteo.HourlyWeatherCode,
+		}
+		res, err := client.GetModelForecasts(ctx, params)
+		return comparisonMsg{
+			ctx:       ctx,
+			forecasts: res,
+			err:       err,
+		}
+	}
+}
+
+// The ensemble model used for the forecast spread. Its 51 members cover the
+// whole globe for the ten days shown on the weather screen.
+const ensembleModel = openmeteo.ECMWFEnsemble
+
+func getEnsembleCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult, units openmeteo.Units) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.EnsembleParams{
+			Latitude:     location.Latitude,
+			Longitude:    location.Longitude,
+			Timezone:     "auto",
+			ForecastDays: 10,
+			Models:       []openmeteo.EnsembleModel{ensembleModel},
+			Units:        units,
+			Daily: []openmeteo.DailyVariables{
+				openmeteo.DailyTemperature2mMin,
+				openmeteo.DailyTemperature2mMax,
 			},
 		}
-		res, err := openmeteo.GetForecast(params)
-		if err != nil {
-			return errorMsg{
-				err: err,
-			}
+		res, err := client.GetEnsemble(ctx, params)
+		return ensembleMsg{
+			ctx:      ctx,
+			ensemble: res,
+			err:      err,
+		}
+	}
+}
+
+// Loads the climate normals of the location from the cache, fetching and
+// caching them on the first visit. Failing to cache them is not an error.
+func getClimateNormalsCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult) tea.Cmd {
+	return func() tea.Msg {
+		if normals, ok, err := store.LoadClimateNormals(location); err == nil && ok {
+			return normalsMsg{ctx: ctx, normals: normals}
+		}
+		normals, err := client.GetClimateNormals(ctx, location.Latitude, location.Longitude)
+		if err == nil {
+			_ = store.SaveClimateNormals(location, normals)
+		}
+		return normalsMsg{
+			ctx:     ctx,
+			normals: normals,
+			err:     err,
 		}
-		return dataMsg{
-			forecast: res,
+	}
+}
+
+func getMarineCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult, units openmeteo.Units) tea.Cmd {
+	return func() tea.Msg {
+		lengthUnit := openmeteo.MetricLength
+		if units.System() == "imperial" {
+			lengthUnit = openmeteo.ImperialLength
+		}
+		params := openmeteo.MarineParams{
+			Latitude:      location.Latitude,
+			Longitude:     location.Longitude,
+			Timezone:      "auto",
+			ForecastHours: 12,
+			// Wave heights follow the unit system, the sea temperature the
+			// temperature unit, as elsewhere on the screen.
+			LengthUnit:      lengthUnit,
+			TemperatureUnit: units.Temperature,
+			Current: []openmeteo.MarineVariables{
+				openmeteo.MarineWaveHeight,
+				openmeteo.MarineWavePeriod,
+				openmeteo.MarineWaveDirection,
+				openmeteo.MarineSwellWaveHeight,
+				openmeteo.MarineSwellWavePeriod,
+				openmeteo.MarineSwellWaveDirection,
+				openmeteo.MarineSeaSurfaceTemperature,
+			},
+			Hourly: []openmeteo.MarineVariables{
+				openmeteo.MarineWaveHeight,
+			},
+		}
+		res, err := client.GetMarine(ctx, params)
+		return marineMsg{
+			ctx:    ctx,
+			marine: res,
+			err:    err,
 		}
 	}
 }
@@ -67,3 +338,91 @@ func saveRecentLocationCmd(location openmeteo.GeocodingResult) tea.Cmd {
 		return savedMsg{err: err}
 	}
 }
+
+func saveElevationCmd(location openmeteo.GeocodingResult, elevation *float64) tea.Cmd {
+	return func() tea.Msg {
+		err := store.SetElevationOverride(location, elevation)
+		return savedMsg{err: err}
+	}
+}
+
+func saveUnitsCmd(units openmeteo.Units) tea.Cmd {
+	return func() tea.Msg {
+		err := store.SaveUnits(units)
+		return savedMsg{err: err}
+	}
+}
+
+// Days of river discharge forecast shown, the API goes up to 210.
+const floodForecastDays = 30
+
+// Fetches the river discharge forecast. For locations by a river, the usual
+// range of the discharge is fetched too unless band already holds it, see
+// getDischargeBand. The forecast is still shown without the range when that
+// request fails.
+func getFloodCmd(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult, band openmeteo.DischargeBand) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.FloodParams{
+			Latitude:     location.Latitude,
+			Longitude:    location.Longitude,
+			Timezone:     "auto",
+			ForecastDays: floodForecastDays,
+			Daily: []openmeteo.FloodVariables{
+				openmeteo.FloodRiverDischargeMedian,
+				openmeteo.FloodRiverDischargeMax,
+			},
+		}
+		res, err := client.GetFlood(ctx, params)
+		if err == nil && hasRiver(res) && len(band.High) == 0 {
+			band = getDischargeBand(ctx, client, location)
+		}
+		return floodMsg{
+			ctx:   ctx,
+			flood: res,
+			band:  band,
+			err:   err,
+		}
+	}
+}
+
+// Gets the discharge band of a location from the disk cache, or from the API
+// then caches it. The band covers a past period, so it is fetched only once.
+// Empty when the request fails.
+func getDischargeBand(ctx context.Context, client *openmeteo.Client, location openmeteo.GeocodingResult) openmeteo.DischargeBand {
+	if band, ok, err := store.LoadDischargeBand(location); err == nil && ok {
+		return band
+	}
+	band, err := client.GetDischargeBand(ctx, location.Latitude, location.Longitude)
+	if err != nil {
+		return openmeteo.DischargeBand{}
+	}
+	_ = store.SaveDischargeBand(location, band)
+	return band
+}
+
+func saveHideFloodCmd(hide bool) tea.Cmd {
+	return func() tea.Msg {
+		err := store.UpdateSettings(func(settings *store.Settings) {
+			settings.HideFlood = hide
+		})
+		return savedMsg{err: err}
+	}
+}
+
+func saveHideMarineCmd(hide bool) tea.Cmd {
+	return func() tea.Msg {
+		err := store.UpdateSettings(func(settings *store.Settings) {
+			settings.HideMarine = hide
+		})
+		return savedMsg{err: err}
+	}
+}
+
+func saveModelCmd(model openmeteo.WeatherModel) tea.Cmd {
+	return func() tea.Msg {
+		err := store.UpdateSettings(func(settings *store.Settings) {
+			settings.Model = model
+		})
+		return savedMsg{err: err}
+	}
+}
@@ -2,9 +2,13 @@ package weather
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/tui/format"
 )
 
 func formatMeasurement(measurement openmeteo.FloatMeasurement) string {
@@ -18,33 +22,207 @@ func formatValueWithUnit(value float64, unit string) string {
 	return fmt.Sprintf("%.1f %s", value, unit)
 }
 
-func formatHourlyTime(raw string) string {
-	if raw == "" {
-		return "-"
+// Formats the range of the ensemble members at step i, e.g. "18–24 °C".
+// Falls back to fallback when the step has no data.
+func formatSpread(spread openmeteo.EnsembleSeries, i int, fallback string) string {
+	low, okLow := spread.Min.At(i)
+	high, okHigh := spread.Max.At(i)
+	if !okLow || !okHigh {
+		return fallback
 	}
-	const inputLayout = "2006-01-02T15:04"
-	t, err := time.Parse(inputLayout, raw)
-	if err != nil {
-		return raw
+	if spread.Min.Unit == "" {
+		return fmt.Sprintf("%.0f–%.0f", low, high)
 	}
-	return t.Format("3 PM")
+	return fmt.Sprintf("%.0f–%.0f %s", low, high, spread.Min.Unit)
 }
 
-func formatDailyDate(raw string) string {
-	if raw == "" {
+func formatHourlyTime(t time.Time) string {
+	if t.IsZero() {
 		return "-"
 	}
-	const inputLayout = "2006-01-02"
-	t, err := time.Parse(inputLayout, raw)
-	if err != nil {
-		return raw
+	return t.Format("3 PM")
+}
+
+func formatDailyDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
 	}
 	return t.Format("Mon 2")
 }
 
+// Concentrations are shown without decimals to fit the column, pollen counts
+// are reported in grains/m³ which is shortened for the same reason.
+func formatAirQualityMeasurement(measurement openmeteo.FloatMeasurement) string {
+	unit := strings.Replace(measurement.Unit, "grains", "gr", 1)
+	if unit == "" {
+		return fmt.Sprintf("%.0f", measurement.Value)
+	}
+	return fmt.Sprintf("%.0f %s", measurement.Value, unit)
+}
+
+// Short labels for the air quality variables, they need to fit the label column.
+func formatAirQualityVariable(variable openmeteo.AirQualityVariables) string {
+	switch variable {
+	case openmeteo.AirQualityPM2_5:
+		return "PM2.5"
+	case openmeteo.AirQualityPM10:
+		return "PM10"
+	case openmeteo.AirQualityOzone:
+		return "Ozone"
+	case openmeteo.AirQualityAlderPollen:
+		return "Alder"
+	case openmeteo.AirQualityBirchPollen:
+		return "Birch"
+	case openmeteo.AirQualityGrassPollen:
+		return "Grass"
+	case openmeteo.AirQualityMugwortPollen:
+		return "Mugwort"
+	case openmeteo.AirQualityOlivePollen:
+		return "Olive"
+	case openmeteo.AirQualityRagweedPollen:
+		return "Ragweed"
+	default:
+		return string(variable)
+	}
+}
+
+// Describes a 15-minute precipitation amount by its hourly rate, using the
+// usual thresholds of 2.5 and 7.6 mm/h between light, moderate and heavy.
+func formatPrecipitationIntensity(amount float64, unit string) string {
+	rate := amount * 4
+	if unit == "inch" {
+		rate *= 25.4
+	}
+	switch {
+	case rate < 2.5:
+		return "Light"
+	case rate < 7.6:
+		return "Moderate"
+	default:
+		return "Heavy"
+	}
+}
+
+func formatClock(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("3:04 PM")
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
 	}
 	return b
 }
+
+// The length of a foot in meters.
+const metersPerFoot = 0.3048
+
+// The unit elevations are shown and entered in, feet with imperial units.
+func elevationUnit(units openmeteo.Units) string {
+	if units.System() == "imperial" {
+		return "ft"
+	}
+	return "m"
+}
+
+// Format an elevation in meters in the unit of units, e.g. "1,870 m" or
+// "6,135 ft".
+func formatElevation(meters float64, units openmeteo.Units) string {
+	value, unit := meters, elevationUnit(units)
+	if unit == "ft" {
+		value = meters / metersPerFoot
+	}
+	return format.Thousands(int(math.Round(value))) + " " + unit
+}
+
+// Parse an elevation entered in the unit of units, e.g. "2400" or "7,875",
+// into meters. An empty input yields nil, no elevation.
+func parseElevation(input string, units openmeteo.Units) (*float64, error) {
+	input = strings.ReplaceAll(strings.TrimSpace(input), ",", "")
+	if input == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%q is not an elevation", input)
+	}
+	if elevationUnit(units) == "ft" {
+		value *= metersPerFoot
+	}
+	return &value, nil
+}
+
+// Convert a temperature in degrees Celsius to unit, the unit of a
+// temperature series.
+func convertCelsius(celsius float64, unit string) float64 {
+	if unit == "°F" {
+		return celsius*9/5 + 32
+	}
+	return celsius
+}
+
+// Format the difference with a normal temperature, e.g. "+4.2°". The long
+// form spells it out, e.g. "4.2° above normal".
+func formatAnomaly(difference float64, long bool) string {
+	difference = math.Round(difference*10) / 10
+	if !long {
+		if difference == 0 {
+			return "±0.0°"
+		}
+		return fmt.Sprintf("%+.1f°", difference)
+	}
+	switch {
+	case difference > 0:
+		return fmt.Sprintf("%+.1f° above normal", difference)
+	case difference < 0:
+		return fmt.Sprintf("%.1f° below normal", -difference)
+	default:
+		return "right at normal"
+	}
+}
+
+// Format a river discharge, with a decimal for small rivers, e.g. "4.2 m³/s"
+// or "1,250 m³/s". The unit is left out when empty.
+func formatDischarge(value float64, unit string) string {
+	var formatted string
+	if value < 10 {
+		formatted = fmt.Sprintf("%.1f", value)
+	} else {
+		formatted = format.Thousands(int(math.Round(value)))
+	}
+	if unit == "" {
+		return formatted
+	}
+	return formatted + " " + unit
+}
+
+// Format a discharge range, e.g. "80–150 m³/s".
+func formatDischargeRange(low, high float64, unit string) string {
+	return formatDischarge(low, "") + "–" + formatDischarge(high, unit)
+}
+
+// How long ago something happened at t, e.g. "just now", "12 min ago" or
+// "3 h ago". Past a day, the date is used instead, e.g. "on Jan 2".
+func formatAge(age time.Duration, t time.Time) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%d min ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%d h ago", int(age.Hours()))
+	default:
+		return "on " + t.Format("Jan 2")
+	}
+}
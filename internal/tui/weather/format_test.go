@@ -0,0 +1,70 @@
+package weather
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvertCelsius(t *testing.T) {
+	tests := []struct {
+		celsius float64
+		unit    string
+		want    float64
+	}{
+		{0, "°C", 0},
+		{21.5, "°C", 21.5},
+		{0, "°F", 32},
+		{100, "°F", 212},
+		{-40, "°F", -40},
+		{20, "", 20},
+	}
+	for _, tt := range tests {
+		if got := convertCelsius(tt.celsius, tt.unit); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("convertCelsius(%v, %q) = %v, want %v", tt.celsius, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAnomaly(t *testing.T) {
+	tests := []struct {
+		name       string
+		difference float64
+		short      string
+		long       string
+	}{
+		{"above", 4.2, "+4.2°", "+4.2° above normal"},
+		{"below", -3.05, "-3.1°", "3.1° below normal"},
+		{"zero", 0, "±0.0°", "right at normal"},
+		{"rounds to zero from above", 0.04, "±0.0°", "right at normal"},
+		{"rounds to zero from below", -0.04, "±0.0°", "right at normal"},
+		{"rounds away from zero", 0.05, "+0.1°", "+0.1° above normal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAnomaly(tt.difference, false); got != tt.short {
+				t.Errorf("formatAnomaly(%v, false) = %q, want %q", tt.difference, got, tt.short)
+			}
+			if got := formatAnomaly(tt.difference, true); got != tt.long {
+				t.Errorf("formatAnomaly(%v, true) = %q, want %q", tt.difference, got, tt.long)
+			}
+		})
+	}
+}
+
+// The normals are in °C, a forecast in °F is compared with them converted.
+func TestFormatAnomalyFahrenheit(t *testing.T) {
+	normal := 20.0 // 68 °F
+	tests := []struct {
+		maxTemp float64
+		want    string
+	}{
+		{75.2, "+7.2° above normal"},
+		{68, "right at normal"},
+		{59, "9.0° below normal"},
+	}
+	for _, tt := range tests {
+		if got := formatAnomaly(tt.maxTemp-convertCelsius(normal, "°F"), true); got != tt.want {
+			t.Errorf("anomaly of %v °F = %q, want %q", tt.maxTemp, got, tt.want)
+		}
+	}
+}
@@ -8,18 +8,29 @@ type keyMap struct {
 	newSearch       key.Binding
 	recentLocations key.Binding
 	refresh         key.Binding
+	units           key.Binding
+	marine          key.Binding
+	flood           key.Binding
+	model           key.Binding
+	compare         key.Binding
+	pastDays        key.Binding
+	elevation       key.Binding
 	quit            key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.up, k.down, k.newSearch, k.recentLocations, k.refresh, k.quit}
+	return []key.Binding{k.up, k.down, k.newSearch, k.recentLocations, k.refresh, k.units, k.marine, k.flood, k.model, k.compare, k.pastDays, k.elevation, k.quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.up}, {k.down},
 		{k.newSearch}, {k.recentLocations},
-		{k.refresh}, {k.quit},
+		{k.refresh}, {k.units},
+		{k.marine}, {k.flood},
+		{k.model}, {k.compare},
+		{k.pastDays}, {k.elevation},
+		{k.quit},
 	}
 }
 
@@ -45,9 +56,65 @@ func newKeyMap() keyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		units: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "metric/imperial"),
+		),
+		marine: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "marine"),
+		),
+		flood: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "river"),
+		),
+		model: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "model"),
+		),
+		compare: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "compare models"),
+		),
+		pastDays: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "past days"),
+		),
+		elevation: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "elevation"),
+		),
 		quit: key.NewBinding(
 			key.WithKeys("q"),
 			key.WithHelp("q", "quit"),
 		),
 	}
 }
+
+type elevationKeyMap struct {
+	save   key.Binding
+	cancel key.Binding
+}
+
+func (k elevationKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.save, k.cancel}
+}
+
+func (k elevationKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.save, k.cancel},
+	}
+}
+
+func newElevationKeyMap() elevationKeyMap {
+	return elevationKeyMap{
+		save: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save, empty for terrain"),
+		),
+		cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
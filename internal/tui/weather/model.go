@@ -1,38 +1,61 @@
 package weather
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/store"
 	"github.com/diegoserranor/clima/internal/tui/theme"
 )
 
-func New(location openmeteo.GeocodingResult, sink io.Writer) Model {
+func New(location openmeteo.GeocodingResult, settings store.Settings, client *openmeteo.Client, sink io.Writer) Model {
 	ellipsis := spinner.New()
 	ellipsis.Spinner = spinner.Ellipsis
 	ellipsis.Style = theme.AccentStyle
 
 	keys := newKeyMap()
 
+	elevationInput := textinput.New()
+	elevationInput.CharLimit = 16
+	elevationInput.Cursor.Style = theme.AccentStyle
+
 	help := help.New().View(keys)
 	help = theme.OuterFrameStyle.Render(help)
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return Model{
-		sink:      sink,
-		dataState: dataLoading,
-		location:  location,
-		ellipsis:  ellipsis,
-		keys:      keys,
-		help:      help,
+		sink:       sink,
+		client:     client,
+		ctx:        ctx,
+		cancel:     cancel,
+		dataState:  dataLoading,
+		location:   location,
+		units:      settings.Units,
+		hideMarine: settings.HideMarine,
+		hideFlood:  settings.HideFlood,
+		model:      settings.Model,
+		ellipsis:   ellipsis,
+		keys:       keys,
+		help:       help,
+
+		forecastCtx:    ctx,
+		elevationInput: elevationInput,
+		elevationKeys:  newElevationKeyMap(),
 	}
 }
 
@@ -52,26 +75,84 @@ const (
 )
 
 type Model struct {
-	sink        io.Writer
-	windowState windowState
-	dataState   dataState
-	errStr      string
-	viewport    viewport.Model
-	keys        keyMap
-	ellipsis    spinner.Model
-	location    openmeteo.GeocodingResult
-	forecast    openmeteo.ForecastResponse
-	help        string
+	sink            io.Writer
+	client          *openmeteo.Client
+	ctx             context.Context    // scopes the requests made for the current location
+	cancel          context.CancelFunc // aborts the requests still in flight for ctx
+	forecastCtx     context.Context    // scopes the latest forecast request, the results of others are dropped
+	cancelForecast  context.CancelFunc // aborts a forecast superseded by a refresh or change of units
+	windowState     windowState
+	dataState       dataState
+	err             error
+	updating        bool  // a newer forecast is on its way, the current one stays on screen
+	updateErr       error // why the last update failed, the current forecast stayed on screen
+	viewport        viewport.Model
+	keys            keyMap
+	ellipsis        spinner.Model
+	location        openmeteo.GeocodingResult
+	terrain         *float64 // elevation of the location, looked up with the first forecast
+	units           openmeteo.Units
+	model           openmeteo.WeatherModel
+	forecast        openmeteo.ForecastResponse
+	forecastUnits   openmeteo.Units        // units of the forecast on screen
+	forecastModel   openmeteo.WeatherModel // model of the forecast on screen
+	history         historyMsg
+	historyState    dataState
+	airQuality      openmeteo.AirQualityResponse
+	airQualityState dataState
+	ensemble        openmeteo.EnsembleResponse
+	ensembleState   dataState
+	marine          openmeteo.MarineResponse
+	marineState     dataState
+	normals         openmeteo.ClimateNormals
+	normalsState    dataState
+	hideMarine      bool
+	flood           openmeteo.FloodResponse
+	floodBand       openmeteo.DischargeBand // usual discharge range, fetched once per location
+	floodState      dataState
+	hideFlood       bool
+	showPast        bool // includes the past days in the daily forecast
+	comparing       bool // shows the model comparison in place of the daily forecast
+	comparison      map[openmeteo.WeatherModel]openmeteo.ForecastResponse
+	comparisonState dataState
+	help            string
+	editing         bool // the elevation prompt takes the keys in place of the help
+	elevationInput  textinput.Model
+	elevationKeys   elevationKeyMap
+	elevationErr    error // why the elevation entered was rejected
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		saveRecentLocationCmd(m.location),
-		getForecastCmd(m.location.Latitude, m.location.Longitude),
+		getCachedForecastCmd(m.ctx, m.client, forecastParams(m.location, m.units, m.model), m.terrain),
+		getAirQualityCmd(m.ctx, m.client, m.location),
+		getEnsembleCmd(m.ctx, m.client, m.location, m.units),
+		getMarineCmd(m.ctx, m.client, m.location, m.units),
+		getFloodCmd(m.ctx, m.client, m.location, m.floodBand),
+		getClimateNormalsCmd(m.ctx, m.client, m.location),
+		clockCmd(m.ctx),
 		m.ellipsis.Tick,
 	)
 }
 
+// Starts a new forecast request for the current location, aborting the
+// previous one if it is still in flight. A forecast on screen stays there
+// until the new one arrives. A refresh skips the cache.
+func (m Model) fetchForecast(refresh bool) (Model, tea.Cmd) {
+	if m.cancelForecast != nil {
+		m.cancelForecast()
+	}
+	var ctx context.Context
+	ctx, m.cancelForecast = context.WithCancel(m.ctx)
+	if refresh {
+		ctx = openmeteo.BypassCache(ctx)
+	}
+	m.forecastCtx = ctx
+	m.updating = m.dataState == dataReady
+	return m, getForecastCmd(ctx, m.client, forecastParams(m.location, m.units, m.model), m.terrain)
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var (
 		cmd  tea.Cmd
@@ -86,16 +167,87 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updateElevation(msg)
+		}
+		if key.Matches(msg, m.keys.elevation) && m.dataState == dataReady {
+			m.editing = true
+			m.elevationErr = nil
+			m.elevationInput.Prompt = fmt.Sprintf("Elevation in %s: ", elevationUnit(m.units))
+			m.elevationInput.Placeholder = "terrain"
+			m.elevationInput.SetValue("")
+			if m.location.ElevationOverride != nil {
+				value := *m.location.ElevationOverride
+				if elevationUnit(m.units) == "ft" {
+					value /= metersPerFoot
+				}
+				m.elevationInput.SetValue(strconv.Itoa(int(math.Round(value))))
+			}
+			m.elevationInput.CursorEnd()
+			return m, m.elevationInput.Focus()
+		}
 		if key.Matches(msg, m.keys.newSearch) {
+			m.cancel()
 			cmds = append(cmds, requestNewSearchCmd())
 		}
 		if key.Matches(msg, m.keys.recentLocations) {
+			m.cancel()
 			cmds = append(cmds, requestRecentCmd())
 		}
-		if key.Matches(msg, m.keys.refresh) && m.dataState == dataReady {
-			m.dataState = dataLoading
-			batched := tea.Batch(getForecastCmd(m.location.Latitude, m.location.Longitude), m.ellipsis.Tick)
-			cmds = append(cmds, batched)
+		canRetry := m.dataState == dataError && openmeteo.IsRetryable(m.err)
+		if key.Matches(msg, m.keys.refresh) && (m.dataState == dataReady || canRetry) {
+			if m.dataState != dataReady {
+				m.dataState = dataLoading
+			}
+			var fetch, panels tea.Cmd
+			m, fetch = m.fetchForecast(true)
+			m, panels = m.refreshPanels()
+			cmds = append(cmds, fetch, panels, m.ellipsis.Tick)
+		}
+		// Keep the current forecast on screen until the converted one arrives,
+		// so the viewport keeps its scroll position.
+		if key.Matches(msg, m.keys.units) && m.dataState == dataReady {
+			m.units = m.units.Toggle()
+			m.historyState = dataLoading
+			var fetch tea.Cmd
+			m, fetch = m.fetchForecast(false)
+			cmds = append(
+				cmds,
+				fetch,
+				getEnsembleCmd(m.ctx, m.client, m.location, m.units),
+				getMarineCmd(m.ctx, m.client, m.location, m.units),
+				saveUnitsCmd(m.units),
+			)
+			m, cmd = m.invalidateComparison()
+			cmds = append(cmds, cmd)
+		}
+		if key.Matches(msg, m.keys.model) && m.dataState == dataReady {
+			m.model = m.model.Next()
+			var fetch tea.Cmd
+			m, fetch = m.fetchForecast(false)
+			cmds = append(cmds, fetch, saveModelCmd(m.model))
+		}
+		if key.Matches(msg, m.keys.pastDays) && m.dataState == dataReady {
+			m.showPast = !m.showPast
+			m = m.setContent()
+		}
+		if key.Matches(msg, m.keys.compare) && m.dataState == dataReady {
+			m.comparing = !m.comparing
+			if m.comparing && m.comparisonState != dataReady {
+				m.comparisonState = dataLoading
+				cmds = append(cmds, getComparisonCmd(m.ctx, m.client, m.location, m.units))
+			}
+			m = m.setContent()
+		}
+		if key.Matches(msg, m.keys.marine) && m.dataState == dataReady {
+			m.hideMarine = !m.hideMarine
+			m = m.setContent()
+			cmds = append(cmds, saveHideMarineCmd(m.hideMarine))
+		}
+		if key.Matches(msg, m.keys.flood) && m.dataState == dataReady {
+			m.hideFlood = !m.hideFlood
+			m = m.setContent()
+			cmds = append(cmds, saveHideFloodCmd(m.hideFlood))
 		}
 		if key.Matches(msg, m.keys.quit) {
 			cmds = append(cmds, tea.Quit)
@@ -109,24 +261,164 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.viewport.Height = msg.Height - lipgloss.Height(m.help)
 		}
 	case dataMsg:
+		if msg.ctx != m.forecastCtx {
+			break
+		}
+		wasOffline := m.offline()
 		m.forecast = msg.forecast
+		m.forecastUnits = m.units
+		m.forecastModel = m.model
+		m.terrain = msg.terrain
 		m.dataState = dataReady
-
-		// build the body once, when data is ready
-		frameX, _ := theme.OuterFrameStyle.GetFrameSize()
-		innerWidth := m.viewport.Width - frameX
-
-		header := renderHeader(m.location)
-		current := renderCurrent(m.forecast)
-		currentDetails := renderCurrentDetails(m.forecast)
-		hourly := renderHourly(innerWidth, m.forecast)
-		daily := renderDaily(innerWidth, m.forecast)
-		body := renderBody(innerWidth, header, current, currentDetails, hourly, daily)
-
-		m.viewport.SetContent(theme.OuterFrameStyle.Render(body))
+		m.updating = false
+		m.updateErr = msg.err
+		// Show the cached forecast right away, and fetch a newer one behind it
+		// when it is due.
+		if msg.cached && msg.err == nil && time.Since(m.forecast.FetchedAt) >= openmeteo.FORECAST_CACHE_TTL {
+			m, cmd = m.fetchForecast(false)
+			cmds = append(cmds, cmd)
+		}
+		// Back online, the panels that failed meanwhile get another chance.
+		if wasOffline && !m.offline() {
+			m, cmd = m.refreshPanels()
+			cmds = append(cmds, cmd)
+		}
+		// History only depends on the location and units, fetch it once the
+		// forecast tells us the local date.
+		if m.historyState == dataLoading {
+			cmds = append(cmds, m.getHistory())
+		}
+		m = m.setContent()
+	case historyMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.history = msg
+		m.historyState = dataReady
+		if msg.err != nil {
+			m.historyState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case airQualityMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.airQuality = msg.airQuality
+		m.airQualityState = dataReady
+		if msg.err != nil {
+			m.airQualityState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case comparisonMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.comparison = msg.forecasts
+		m.comparisonState = dataReady
+		if msg.err != nil {
+			m.comparisonState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case ensembleMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.ensemble = msg.ensemble
+		m.ensembleState = dataReady
+		if msg.err != nil {
+			m.ensembleState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case marineMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.marine = msg.marine
+		m.marineState = dataReady
+		if msg.err != nil {
+			m.marineState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case floodMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.flood = msg.flood
+		m.floodBand = msg.band
+		m.floodState = dataReady
+		if msg.err != nil {
+			m.floodState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case normalsMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		m.normals = msg.normals
+		m.normalsState = dataReady
+		if msg.err != nil {
+			m.normalsState = dataError
+		}
+		if m.dataState == dataReady {
+			m = m.setContent()
+		}
+	case cacheMissMsg:
+		if msg.ctx != m.forecastCtx {
+			break
+		}
+		m, cmd = m.fetchForecast(false)
+		cmds = append(cmds, cmd)
+	case clockMsg:
+		if msg.ctx != m.ctx {
+			break
+		}
+		cmds = append(cmds, clockCmd(m.ctx))
+		// Without network and nothing cached, keep trying until the network
+		// is back.
+		if m.dataState == dataError && m.offline() {
+			m, cmd = m.fetchForecast(true)
+			cmds = append(cmds, cmd)
+		}
+		if m.dataState != dataReady {
+			break
+		}
+		// Retry a failed update, or revalidate a forecast that went stale on
+		// screen.
+		stale := time.Since(m.forecast.FetchedAt) >= openmeteo.FORECAST_CACHE_TTL
+		if !m.updating && (openmeteo.IsRetryable(m.updateErr) || m.updateErr == nil && stale) {
+			m, cmd = m.fetchForecast(m.updateErr != nil)
+			cmds = append(cmds, cmd)
+		}
+		m = m.setContent()
 	case errorMsg:
+		// The request belongs to a location or forecast we moved away from.
+		if msg.ctx != m.forecastCtx {
+			break
+		}
+		// Keep the forecast on screen when updating it fails, along with its
+		// units and model.
+		if m.updating {
+			m.updating = false
+			m.updateErr = msg.err
+			m, cmd = m.restoreSettings()
+			cmds = append(cmds, cmd)
+			m = m.setContent()
+			break
+		}
 		m.dataState = dataError
-		m.errStr = msg.err.Error()
+		m.err = msg.err
 	}
 
 	if m.dataState == dataLoading {
@@ -134,12 +426,173 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	// Keep the cursor of the elevation prompt blinking.
+	if m.editing {
+		m.elevationInput, cmd = m.elevationInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
+// Handles the keys while the elevation prompt is open. Saving stores the
+// elevation with the location and fetches the forecast for it, an empty input
+// goes back to the terrain elevation.
+func (m Model) updateElevation(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if key.Matches(msg, m.elevationKeys.cancel) {
+		m.editing = false
+		m.elevationInput.Blur()
+		return m, nil
+	}
+	if !key.Matches(msg, m.elevationKeys.save) {
+		var cmd tea.Cmd
+		m.elevationInput, cmd = m.elevationInput.Update(msg)
+		return m, cmd
+	}
+
+	elevation, err := parseElevation(m.elevationInput.Value(), m.units)
+	if err != nil {
+		m.elevationErr = err
+		return m, nil
+	}
+	m.editing = false
+	m.elevationInput.Blur()
+	m.location.ElevationOverride = elevation
+	var fetch tea.Cmd
+	m, fetch = m.fetchForecast(false)
+	m = m.setContent()
+	return m, tea.Batch(fetch, saveElevationCmd(m.location, elevation))
+}
+
+// Whether the forecast could not be fetched for lack of network. The forecast
+// on screen, if any, is the last one cached.
+func (m Model) offline() bool {
+	if m.dataState == dataError {
+		return errors.Is(m.err, openmeteo.ErrNetwork)
+	}
+	return errors.Is(m.updateErr, openmeteo.ErrNetwork)
+}
+
+// Fetches the panels around the forecast again, on a refresh or when the
+// network is back. The normals are only fetched again if they failed.
+func (m Model) refreshPanels() (Model, tea.Cmd) {
+	cmds := []tea.Cmd{
+		getAirQualityCmd(m.ctx, m.client, m.location),
+		getEnsembleCmd(m.ctx, m.client, m.location, m.units),
+		getMarineCmd(m.ctx, m.client, m.location, m.units),
+		getFloodCmd(m.ctx, m.client, m.location, m.floodBand),
+	}
+	if m.normalsState == dataError {
+		m.normalsState = dataLoading
+		cmds = append(cmds, getClimateNormalsCmd(m.ctx, m.client, m.location))
+	}
+	if m.historyState == dataError {
+		m.historyState = dataLoading
+	}
+	m, cmd := m.invalidateComparison()
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+// Fetches the history of the location, the forecast tells the local date.
+func (m Model) getHistory() tea.Cmd {
+	today := m.forecast.CurrentTime
+	if today.IsZero() {
+		today = time.Now().In(m.forecast.Location)
+	}
+	return getHistoryCmd(m.ctx, m.client, m.location, m.units, today)
+}
+
+// Goes back to the units and model of the forecast on screen after failing to
+// fetch it in others, with the panels that follow the units.
+func (m Model) restoreSettings() (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	if m.units != m.forecastUnits {
+		m.units = m.forecastUnits
+		cmds = append(
+			cmds,
+			getEnsembleCmd(m.ctx, m.client, m.location, m.units),
+			getMarineCmd(m.ctx, m.client, m.location, m.units),
+			saveUnitsCmd(m.units),
+		)
+		if m.historyState == dataLoading {
+			cmds = append(cmds, m.getHistory())
+		}
+		var cmd tea.Cmd
+		m, cmd = m.invalidateComparison()
+		cmds = append(cmds, cmd)
+	}
+	if m.model != m.forecastModel {
+		m.model = m.forecastModel
+		cmds = append(cmds, saveModelCmd(m.model))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// Drops the model comparison after a change that makes it outdated. It is
+// fetched again right away when on screen, otherwise the next time it is shown.
+func (m Model) invalidateComparison() (Model, tea.Cmd) {
+	m.comparisonState = dataLoading
+	if !m.comparing {
+		return m, nil
+	}
+	return m, getComparisonCmd(m.ctx, m.client, m.location, m.units)
+}
+
+// Build the body from the current forecast. The viewport keeps its scroll
+// offset, so this can be called again whenever the data changes.
+func (m Model) setContent() Model {
+	frameX, _ := theme.OuterFrameStyle.GetFrameSize()
+	innerWidth := m.viewport.Width - frameX
+
+	now := time.Now()
+
+	status := renderUpdated(m.forecast.FetchedAt, now, m.updating, m.updateErr)
+	var banner string
+	if m.offline() {
+		status = ""
+		banner = renderOffline(m.forecast.FetchedAt, now)
+	}
+	header := renderHeader(m.location, m.terrain, m.forecast.Elevation, m.units, m.model, status, banner, renderNowcast(m.forecast, now))
+	// Without the normals there are no anomalies to annotate.
+	var normals openmeteo.ClimateNormals
+	if m.normalsState == dataReady {
+		normals = m.normals
+	}
+	current := renderCurrent(m.forecast)
+	currentDetails := renderCurrentDetails(m.forecast, normals, now)
+	hourly := renderHourly(innerWidth, m.forecast, now)
+	// Without the ensemble the daily columns show the deterministic forecast alone.
+	var ensemble openmeteo.EnsembleResponse
+	if m.ensembleState == dataReady {
+		ensemble = m.ensemble
+	}
+	var daily string
+	if m.comparing {
+		daily = renderComparison(innerWidth, m.comparison, m.comparisonState, now)
+	} else {
+		daily = renderDaily(innerWidth, m.forecast, ensemble, normals, now, m.showPast)
+	}
+	airQuality := renderAirQuality(innerWidth, m.airQuality, m.airQualityState, now)
+	// The marine panel only shows up for coastal locations, where the API has data.
+	var marine string
+	if m.marineState == dataReady && m.marine.HasData() && !m.hideMarine {
+		marine = renderMarine(innerWidth, m.marine, now)
+	}
+	// Likewise, the river panel only shows up next to rivers.
+	var flood string
+	if m.floodState == dataReady && hasRiver(m.flood) && !m.hideFlood {
+		flood = renderFlood(innerWidth, m.flood, m.floodBand, now)
+	}
+	history := renderHistory(innerWidth, m.history, m.historyState)
+	body := renderBody(innerWidth, header, current, currentDetails, hourly, daily, airQuality, marine, flood, history)
+
+	m.viewport.SetContent(theme.OuterFrameStyle.Render(body))
+	return m
+}
+
 func (m Model) View() string {
 	if m.windowState == windowInit {
 		return theme.OuterFrameStyle.Render("Init...")
@@ -148,11 +601,15 @@ func (m Model) View() string {
 	var content string
 	switch m.dataState {
 	case dataError:
-		content = renderError(m.errStr)
+		content = renderError(m.err)
 	case dataLoading:
 		content = renderLoading(m.ellipsis)
 	case dataReady:
-		content = fmt.Sprintf("%s\n%s", m.viewport.View(), m.help)
+		footer := m.help
+		if m.editing {
+			footer = renderElevationPrompt(m.elevationInput, m.elevationKeys, m.elevationErr)
+		}
+		content = fmt.Sprintf("%s\n%s", m.viewport.View(), footer)
 	default:
 		content = "unknown state (weather)"
 	}
@@ -166,6 +623,34 @@ func (m Model) Reset(location openmeteo.GeocodingResult) Model {
 	ellipsis.Style = theme.AccentStyle
 	m.ellipsis = ellipsis
 	m.dataState = dataLoading
+	m.historyState = dataLoading
+	m.airQualityState = dataLoading
+	m.ensembleState = dataLoading
+	m.marineState = dataLoading
+	m.floodState = dataLoading
+	m.floodBand = openmeteo.DischargeBand{}
+	m.normalsState = dataLoading
+	m.comparing = false
+	m.comparisonState = dataLoading
+	m.editing = false
+	m.elevationInput.Blur()
 	m.location = location
+	m.terrain = nil
+	m.err = nil
+	m.updating = false
+	m.updateErr = nil
+	m.forecast = openmeteo.ForecastResponse{}
+	m.history = historyMsg{}
+	m.airQuality = openmeteo.AirQualityResponse{}
+	m.ensemble = openmeteo.EnsembleResponse{}
+	m.marine = openmeteo.MarineResponse{}
+	m.flood = openmeteo.FloodResponse{}
+	m.normals = openmeteo.ClimateNormals{}
+	m.comparison = nil
+	m.cancel()
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	// Init loads the cached forecast with the location ctx.
+	m.forecastCtx = m.ctx
+	m.cancelForecast = nil
 	return m
 }
@@ -0,0 +1,115 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/diegoserranor/clima/internal/openmeteo"
+	"github.com/diegoserranor/clima/internal/store"
+)
+
+func newTestModel(t *testing.T, location openmeteo.GeocodingResult) Model {
+	t.Helper()
+	m := New(location, store.Settings{}, openmeteo.NewClient(), nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return m
+}
+
+// A forecast fetched now, told apart by its elevation.
+func testForecast(elevation float64) openmeteo.ForecastResponse {
+	return openmeteo.ForecastResponse{Elevation: elevation, Location: time.UTC, FetchedAt: time.Now()}
+}
+
+func TestUpdateDropsResultsOfPreviousLocation(t *testing.T) {
+	m := newTestModel(t, openmeteo.GeocodingResult{Name: "Zermatt"})
+	previous := m.ctx
+	m = m.Reset(openmeteo.GeocodingResult{Name: "Lyon"})
+
+	results := []tea.Msg{
+		dataMsg{ctx: previous, forecast: testForecast(1608)},
+		cacheMissMsg{ctx: previous},
+		errorMsg{ctx: previous, err: errors.New("boom")},
+		historyMsg{ctx: previous},
+		airQualityMsg{ctx: previous},
+		comparisonMsg{ctx: previous},
+		ensembleMsg{ctx: previous},
+		marineMsg{ctx: previous},
+		floodMsg{ctx: previous},
+		normalsMsg{ctx: previous},
+	}
+	for _, msg := range results {
+		m, _ = m.Update(msg)
+	}
+
+	if m.dataState != dataLoading || m.forecast.Elevation != 0 {
+		t.Errorf("forecast of the previous location landed, state %d", m.dataState)
+	}
+	for name, state := range map[string]dataState{
+		"history":     m.historyState,
+		"air quality": m.airQualityState,
+		"comparison":  m.comparisonState,
+		"ensemble":    m.ensembleState,
+		"marine":      m.marineState,
+		"flood":       m.floodState,
+		"normals":     m.normalsState,
+	} {
+		if state != dataLoading {
+			t.Errorf("%s of the previous location landed, state %d", name, state)
+		}
+	}
+}
+
+func TestUpdateKeepsResultsOfCurrentLocation(t *testing.T) {
+	m := newTestModel(t, openmeteo.GeocodingResult{Name: "Lyon"})
+	m = m.Reset(m.location)
+
+	m, _ = m.Update(dataMsg{ctx: m.forecastCtx, forecast: testForecast(173)})
+	m, _ = m.Update(normalsMsg{ctx: m.ctx})
+
+	if m.dataState != dataReady || m.forecast.Elevation != 173 {
+		t.Errorf("forecast of the location dropped, state %d", m.dataState)
+	}
+	if m.normalsState != dataReady {
+		t.Errorf("normals of the location dropped, state %d", m.normalsState)
+	}
+}
+
+func TestUpdateDropsSupersededForecast(t *testing.T) {
+	m := newTestModel(t, openmeteo.GeocodingResult{Name: "Lyon"})
+	m = m.Reset(m.location)
+	m, _ = m.Update(dataMsg{ctx: m.forecastCtx, forecast: testForecast(173)})
+
+	superseded := m.forecastCtx
+	m, _ = m.fetchForecast(true)
+	m, _ = m.Update(dataMsg{ctx: superseded, forecast: testForecast(999)})
+
+	if m.forecast.Elevation != 173 || !m.updating {
+		t.Errorf("superseded forecast landed, elevation %v, updating %v", m.forecast.Elevation, m.updating)
+	}
+}
+
+func TestResetClearsLocationState(t *testing.T) {
+	m := newTestModel(t, openmeteo.GeocodingResult{Name: "Zermatt"})
+	m = m.Reset(m.location)
+	m, _ = m.Update(dataMsg{ctx: m.forecastCtx, forecast: testForecast(1608)})
+	m, _ = m.Update(historyMsg{ctx: m.ctx, err: errors.New("boom")})
+	m.err = errors.New("boom")
+
+	m = m.Reset(openmeteo.GeocodingResult{Name: "Lyon"})
+
+	if m.err != nil || m.updateErr != nil {
+		t.Errorf("errors kept: %v, %v", m.err, m.updateErr)
+	}
+	if m.forecast.Elevation != 0 || !m.forecast.FetchedAt.IsZero() {
+		t.Errorf("forecast kept: %+v", m.forecast)
+	}
+	if m.history.err != nil {
+		t.Errorf("history kept: %+v", m.history)
+	}
+	if m.forecastCtx != m.ctx {
+		t.Error("forecast requests are not scoped to the new location")
+	}
+}
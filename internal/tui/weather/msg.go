@@ -1,12 +1,84 @@
 package weather
 
-import "github.com/diegoserranor/clima/internal/openmeteo"
+import (
+	"context"
 
+	"github.com/diegoserranor/clima/internal/openmeteo"
+)
+
+// The forecast of the location. Like the other results, it carries the ctx of
+// its request, so that results of a location or forecast we moved away from
+// can be dropped.
 type dataMsg struct {
+	ctx      context.Context
 	forecast openmeteo.ForecastResponse
+	// terrain is the elevation of the location in meters, nil when unknown.
+	terrain *float64
+	// cached is set when the forecast was loaded from the cache alone.
+	cached bool
+	// err is why a cached forecast stands in for a newer one, usually no network.
+	err error
+}
+
+// No forecast was cached for the location.
+type cacheMissMsg struct {
+	ctx context.Context
+}
+
+// Keeps the age of the forecast on screen current. Ticks of a previous
+// location, with another ctx, are dropped.
+type clockMsg struct {
+	ctx context.Context
+}
+
+// Archived weather for the same day last year and the past week.
+type historyMsg struct {
+	ctx      context.Context
+	lastYear openmeteo.ForecastResponse
+	pastWeek openmeteo.ForecastResponse
+	err      error
+}
+
+type airQualityMsg struct {
+	ctx        context.Context
+	airQuality openmeteo.AirQualityResponse
+	err        error
+}
+
+type comparisonMsg struct {
+	ctx       context.Context
+	forecasts map[openmeteo.WeatherModel]openmeteo.ForecastResponse
+	err       error
+}
+
+type ensembleMsg struct {
+	ctx      context.Context
+	ensemble openmeteo.EnsembleResponse
+	err      error
+}
+
+type marineMsg struct {
+	ctx    context.Context
+	marine openmeteo.MarineResponse
+	err    error
+}
+
+type floodMsg struct {
+	ctx   context.Context
+	flood openmeteo.FloodResponse
+	band  openmeteo.DischargeBand
+	err   error
+}
+
+// Temperature normals of the location, in degrees Celsius.
+type normalsMsg struct {
+	ctx     context.Context
+	normals openmeteo.ClimateNormals
+	err     error
 }
 
 type errorMsg struct {
+	ctx context.Context
 	err error
 }
 
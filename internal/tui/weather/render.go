@@ -1,10 +1,16 @@
 package weather
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/diegoserranor/clima/internal/openmeteo"
@@ -37,16 +43,39 @@ func renderDefault() string {
 	return theme.OuterFrameStyle.Render("Unknown state (weather forecast screen).")
 }
 
-func renderError(errStr string) string {
-	content := fmt.Sprintf("An error has occurred:\n%s\n\nPress 'q' to quit.", errStr)
+func renderError(err error) string {
+	var hint string
+	switch {
+	case errors.Is(err, openmeteo.ErrRateLimited):
+		hint = "Open-Meteo is limiting requests. Wait a moment, then press 'r' to retry or 'q' to quit."
+	case errors.Is(err, openmeteo.ErrInvalidParams):
+		hint = "The forecast request was rejected. Press 'n' to search another location or 'q' to quit."
+	case errors.Is(err, openmeteo.ErrNetwork):
+		hint = "No forecast of this location is cached to show offline. Retrying every minute, press 'r' to retry now or 'q' to quit."
+	case openmeteo.IsRetryable(err):
+		hint = "Press 'r' to retry or 'q' to quit."
+	default:
+		hint = "Press 'q' to quit."
+	}
+	content := fmt.Sprintf("An error has occurred:\n%s\n\n%s", err, hint)
 	return theme.OuterFrameStyle.Render(content)
 }
 
+// The elevation prompt shown in place of the help, with the reason the last
+// input was rejected if any.
+func renderElevationPrompt(input textinput.Model, keys elevationKeyMap, err error) string {
+	prompt := input.View() + "  " + help.New().View(keys)
+	if err != nil {
+		prompt += "  " + theme.AccentStyle.Render(err.Error())
+	}
+	return theme.OuterFrameStyle.Render(prompt)
+}
+
 func renderLoading(ellipsis spinner.Model) string {
 	return theme.OuterFrameStyle.Render(fmt.Sprintf("Loading forecast%s", ellipsis.View()))
 }
 
-func renderHeader(location openmeteo.GeocodingResult) string {
+func renderHeader(location openmeteo.GeocodingResult, terrain *float64, grid float64, units openmeteo.Units, model openmeteo.WeatherModel, status string, banner string, nowcast string) string {
 	header := location.Name
 	parts := []string{}
 
@@ -56,12 +85,164 @@ func renderHeader(location openmeteo.GeocodingResult) string {
 	if location.Country != "" {
 		parts = append(parts, location.Country)
 	}
-	if len(parts) > 0 {
-		header += "\n" + theme.SubtleStyle.Render(strings.Join(parts, ", "))
+	subtitle := strings.Join(parts, ", ")
+	if elevation := renderElevation(location.ElevationOverride, terrain, grid, units); elevation != "" {
+		if subtitle != "" {
+			subtitle += " · "
+		}
+		subtitle += elevation
+	}
+	if model != "" && model != openmeteo.BestMatch {
+		if subtitle != "" {
+			subtitle += " · "
+		}
+		subtitle += model.Label() + " model"
+	}
+	if status != "" {
+		if subtitle != "" {
+			subtitle += " · "
+		}
+		subtitle += status
+	}
+	if subtitle != "" {
+		header += "\n" + theme.SubtleStyle.Render(subtitle)
+	}
+	if banner != "" {
+		header += "\n\n" + banner
+	}
+	if nowcast != "" {
+		if banner != "" {
+			header += "\n" + nowcast
+		} else {
+			header += "\n\n" + nowcast
+		}
 	}
 	return lipgloss.NewStyle().MarginBottom(1).Render(header)
 }
 
+// How old the forecast on screen is, e.g. "Updated 12 min ago", followed by
+// whether a newer one is on its way or failed to arrive.
+func renderUpdated(fetchedAt time.Time, now time.Time, updating bool, updateErr error) string {
+	if fetchedAt.IsZero() {
+		return ""
+	}
+	status := "Updated " + formatAge(now.Sub(fetchedAt), fetchedAt)
+	switch {
+	case updating:
+		status += ", updating…"
+	case updateErr != nil:
+		status += ", update failed"
+	}
+	return status
+}
+
+// Tells that the forecast on screen is the last one cached, e.g.
+// "Offline — data from 8:10 AM". The date comes along when it is not today's.
+func renderOffline(fetchedAt time.Time, now time.Time) string {
+	fetchedAt = fetchedAt.In(now.Location())
+	from := formatClock(fetchedAt)
+	if y, m, d := fetchedAt.Date(); y != now.Year() || m != now.Month() || d != now.Day() {
+		from = fetchedAt.Format("Jan 2") + ", " + from
+	}
+	return theme.AccentStyle.Render("Offline — data from " + from)
+}
+
+// The elevation the forecast is for, e.g. "1,870 m". With an elevation set by
+// the user, the terrain elevation follows, e.g. "2,400 m set, terrain 1,870 m".
+// The elevation of the grid cell the forecast was computed at comes last when
+// it differs, e.g. "1,870 m (grid 1,420 m)".
+func renderElevation(override *float64, terrain *float64, grid float64, units openmeteo.Units) string {
+	var elevation string
+	requested := override
+	switch {
+	case override != nil && terrain != nil:
+		elevation = fmt.Sprintf("%s set, terrain %s", formatElevation(*override, units), formatElevation(*terrain, units))
+	case override != nil:
+		elevation = formatElevation(*override, units) + " set"
+	case terrain != nil:
+		elevation = formatElevation(*terrain, units)
+		requested = terrain
+	}
+	// A fraction of a meter apart is the same elevation.
+	if requested != nil && math.Round(*requested) == math.Round(grid) {
+		return elevation
+	}
+	if elevation == "" {
+		return "grid " + formatElevation(grid, units)
+	}
+	return elevation + " (grid " + formatElevation(grid, units) + ")"
+}
+
+// How far ahead the nowcast looks for precipitation.
+const nowcastWindow = 2 * time.Hour
+
+// How long past the window the 15-minute series runs, so the nowcast stays
+// whole until the forecast is next refreshed, or a while offline.
+const nowcastMargin = 3 * time.Hour
+
+// Summarizes the precipitation expected in the nowcast window from the
+// 15-minute series, e.g. "Light rain starting 2:15 PM, ending 3:00 PM".
+func renderNowcast(forecast openmeteo.ForecastResponse, now time.Time) string {
+	precipitation, ok := forecast.Minutely15Series(openmeteo.Minutely15Precipitation)
+	if !ok {
+		return ""
+	}
+	snowfall, _ := forecast.Minutely15Series(openmeteo.Minutely15Snowfall)
+
+	times := forecast.Minutely15Times
+	// A forecast cached long ago no longer covers the window, better say
+	// nothing than miss the rain at its end.
+	if len(times) == 0 || times[len(times)-1].Add(15*time.Minute).Before(now.Add(nowcastWindow)) {
+		return ""
+	}
+	first := max(times.IndexAt(now), 0)
+	_, last := times.Between(now, now.Add(nowcastWindow))
+	if first >= last {
+		return ""
+	}
+
+	wet := func(i int) bool {
+		value, ok := precipitation.At(i)
+		return ok && value > 0
+	}
+	start := first
+	for start < last && !wet(start) {
+		start++
+	}
+	if start == last {
+		return theme.SubtleStyle.Render("No precipitation expected in the next 2 hours")
+	}
+	end := start
+	peak := 0.0
+	isSnow := false
+	for end < last && wet(end) {
+		if value, _ := precipitation.At(end); value > peak {
+			peak = value
+			snow, _ := snowfall.At(end)
+			isSnow = snow > 0
+		}
+		end++
+	}
+
+	kind := "rain"
+	if isSnow {
+		kind = "snow"
+	}
+	summary := formatPrecipitationIntensity(peak, precipitation.Unit) + " " + kind
+	switch {
+	case start > first:
+		summary += " starting " + formatClock(times[start])
+		if end < last {
+			summary += ", ending " + formatClock(times[end])
+		}
+	case end < last:
+		summary += ", ending " + formatClock(times[end])
+	default:
+		summary += " for the next 2 hours"
+	}
+	return theme.AccentStyle.Render(summary)
+}
+
 func renderCurrent(forecast openmeteo.ForecastResponse) string {
 	var current string
 	if weatherCode, ok := forecast.CurrentMeasurement(openmeteo.CurrentWeatherCode); ok {
@@ -85,16 +266,20 @@ func renderCurrent(forecast openmeteo.ForecastResponse) string {
 	return current + "\n"
 }
 
-func renderCurrentDetails(forecast openmeteo.ForecastResponse) string {
+func renderCurrentDetails(forecast openmeteo.ForecastResponse, normals openmeteo.ClimateNormals, now time.Time) string {
 	var currentdetails string
 	var col1 string
 	var col2 string
 	var col3 string
+	var col4 string
+
+	today := forecast.DailyTimes.IndexAt(now)
 
 	minTempLabel := theme.LabelStyle.Render("Min")
 	var minTempValue string
-	if minSeries, ok := forecast.DailySeries(openmeteo.DailyTemperature2mMin); ok && len(minSeries.Values) > 0 {
-		minTempValue = formatValueWithUnit(minSeries.Values[0], minSeries.Unit)
+	minSeries, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMin)
+	if minTemp, ok := minSeries.At(today); ok {
+		minTempValue = formatValueWithUnit(minTemp, minSeries.Unit)
 	} else {
 		minTempValue = "-"
 	}
@@ -102,8 +287,9 @@ func renderCurrentDetails(forecast openmeteo.ForecastResponse) string {
 
 	maxTempLabel := theme.LabelStyle.Render("Max")
 	var maxTempValue string
-	if maxSeries, ok := forecast.DailySeries(openmeteo.DailyTemperature2mMax); ok && len(maxSeries.Values) > 0 {
-		maxTempValue = formatValueWithUnit(maxSeries.Values[0], maxSeries.Unit)
+	maxSeries, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMax)
+	if maxTemp, ok := maxSeries.At(today); ok {
+		maxTempValue = formatValueWithUnit(maxTemp, maxSeries.Unit)
 	} else {
 		maxTempValue = "-"
 	}
@@ -111,8 +297,9 @@ func renderCurrentDetails(forecast openmeteo.ForecastResponse) string {
 
 	uvLabel := theme.LabelStyle.Render("UV index")
 	var uvValue string
-	if uvSeries, ok := forecast.DailySeries(openmeteo.DailyUVIndexMax); ok && len(uvSeries.Values) > 0 {
-		uvValue = fmt.Sprintf("%.1f", uvSeries.Values[0])
+	uvSeries, _ := forecast.DailySeries(openmeteo.DailyUVIndexMax)
+	if uv, ok := uvSeries.At(today); ok {
+		uvValue = fmt.Sprintf("%.1f", uv)
 	} else {
 		uvValue = "-"
 	}
@@ -163,15 +350,60 @@ func renderCurrentDetails(forecast openmeteo.ForecastResponse) string {
 	}
 	currentdetails += pressureLabel + pressureValue
 	col3 += fmt.Sprintf("%s%s", pressureLabel, pressureValue)
-	col3 = columnWidthStyle.Render(col3)
-
-	currentdetails = lipgloss.JoinHorizontal(lipgloss.Top, col1, col2, col3)
+	col3 = columnWidthStyle.Inherit(columnBorderStyle).MarginRight(2).Render(col3)
+
+	sunriseLabel := theme.LabelStyle.Render("Sunrise")
+	sunriseValue := "-"
+	sunrises, _ := forecast.DailyTimestamps(openmeteo.DailySunrise)
+	sunrise, hasSunrise := sunrises.At(today)
+	if hasSunrise {
+		sunriseValue = formatClock(sunrise)
+	}
+	col4 += fmt.Sprintf("%s%s\n", sunriseLabel, sunriseValue)
+
+	sunsetLabel := theme.LabelStyle.Render("Sunset")
+	sunsetValue := "-"
+	sunsets, _ := forecast.DailyTimestamps(openmeteo.DailySunset)
+	sunset, hasSunset := sunsets.At(today)
+	if hasSunset {
+		sunsetValue = formatClock(sunset)
+	}
+	col4 += fmt.Sprintf("%s%s\n", sunsetLabel, sunsetValue)
+
+	// Prefer the daylight duration reported by the API, fall back to the time between sunrise and sunset.
+	dayLengthLabel := theme.LabelStyle.Render("Day length")
+	dayLengthValue := "-"
+	daylightSeries, _ := forecast.DailySeries(openmeteo.DailyDaylightDuration)
+	if daylight, ok := daylightSeries.At(today); ok {
+		dayLengthValue = formatDuration(time.Duration(daylight * float64(time.Second)))
+	} else if hasSunrise && hasSunset {
+		dayLengthValue = formatDuration(sunset.Sub(sunrise))
+	}
+	col4 += fmt.Sprintf("%s%s", dayLengthLabel, dayLengthValue)
+	col4 = columnWidthStyle.Render(col4)
+
+	currentdetails = lipgloss.JoinHorizontal(lipgloss.Top, col1, col2, col3, col4)
+
+	// Compare today's high with the normal high of the date.
+	if today >= 0 && today < len(forecast.DailyTimes) {
+		if maxTemp, ok := maxSeries.At(today); ok {
+			if normal, ok := normals.At(forecast.DailyTimes[today]); ok {
+				normalMax := convertCelsius(normal.Max, maxSeries.Unit)
+				anomaly := fmt.Sprintf("Today's high is %s (normal %s, %s)",
+					formatAnomaly(maxTemp-normalMax, true), formatValueWithUnit(normalMax, maxSeries.Unit), normals.Period)
+				currentdetails += "\n\n" + theme.SubtleStyle.Render(anomaly)
+			}
+		}
+	}
 
 	return lipgloss.NewStyle().PaddingBottom(1).Render(currentdetails)
 }
 
+// The most hours the hourly forecast shows, however wide the terminal.
+const hourlyColumns = 10
+
 // Renders the forecast for the next few hours except for the current hour. The number of rendered hours depends on the total width available.
-func renderHourly(width int, forecast openmeteo.ForecastResponse) string {
+func renderHourly(width int, forecast openmeteo.ForecastResponse, now time.Time) string {
 	// cw -> the width of the column without right margin
 	// mr -> the right margin for every column except the last
 	// width -> total available width
@@ -182,63 +414,50 @@ func renderHourly(width int, forecast openmeteo.ForecastResponse) string {
 		return theme.SubtleStyle.Render("The terminal window is too small")
 	}
 
-	// We need at least "now" + 1 future hour to do anything useful.
-	if len(forecast.HourlyTimes) < 2 {
+	// Start right after the hour containing now, which is not necessarily the
+	// first step of the series. We need at least 1 future hour to do anything useful.
+	first := forecast.HourlyTimes.IndexAt(now) + 1
+	if first >= len(forecast.HourlyTimes) {
 		return theme.SubtleStyle.Render("Hourly forecast unavailable")
 	}
-	hourlySeries := forecast.HourlyTimes[1:]
-	hourCount := len(hourlySeries)
+	hourCount := len(forecast.HourlyTimes) - first
 
 	// Clamp max allowed to the available hourly data series from the API response
-	maxAvailable := hourCount
+	maxAvailable := min(hourCount, hourlyColumns)
 	if maxAllowed > maxAvailable {
 		maxAllowed = maxAvailable
 	}
 
-	var wmoSeries []float64
-	weatherCodes, hasWeatherCodes := forecast.HourlySeries(openmeteo.HourlyWeatherCode)
-	if hasWeatherCodes {
-		wmoSeries = weatherCodes.Values[1:]
-	}
-
-	var tempSeries []float64
-	temperatures, hasTemperatures := forecast.HourlySeries(openmeteo.HourlyTemperature2m)
-	if hasTemperatures {
-		tempSeries = temperatures.Values[1:]
-	}
+	weatherCodes, _ := forecast.HourlySeries(openmeteo.HourlyWeatherCode)
+	temperatures, _ := forecast.HourlySeries(openmeteo.HourlyTemperature2m)
 
 	cols := make([]string, 0, maxAllowed)
 	for i := range maxAllowed {
-		timeStr := theme.SubtleStyle.Render(formatHourlyTime(hourlySeries[i]))
+		hour := first + i
+		timeStr := theme.SubtleStyle.Render(formatHourlyTime(forecast.HourlyTimes[hour]))
 
 		wmoStr := "-"
-		if hasWeatherCodes {
-			if mapped := openmeteo.MapWeatherCode(wmoSeries[i]); mapped != "" {
+		if code, ok := weatherCodes.At(hour); ok {
+			if mapped := openmeteo.MapWeatherCode(code); mapped != "" {
 				wmoStr = theme.AccentStyle.Render(mapped)
 			}
 		}
 
 		tempStr := "-"
-		if hasTemperatures {
-			tempStr = formatValueWithUnit(tempSeries[i], temperatures.Unit)
+		if temperature, ok := temperatures.At(hour); ok {
+			tempStr = formatValueWithUnit(temperature, temperatures.Unit)
 		}
 
-		column := lipgloss.JoinVertical(lipgloss.Left, timeStr, wmoStr, tempStr)
-		style := columnWidthStyle
-		if i != maxAllowed-1 {
-			style = style.Inherit(columnBorderStyle).MarginRight(mr)
-		}
-		column = style.Render(column)
-		cols = append(cols, column)
+		cols = append(cols, lipgloss.JoinVertical(lipgloss.Left, timeStr, wmoStr, tempStr))
 	}
 
-	hourColumns := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+	hourColumns := joinColumns(cols, mr)
 	hourly := lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render("Next few hours"), hourColumns)
 	return lipgloss.NewStyle().PaddingBottom(1).Render(hourly)
 }
 
 // Renders the forecast for the next few days except for the current day. The number of days rendered depends on the available width.
-func renderDaily(width int, forecast openmeteo.ForecastResponse) string {
+func renderDaily(width int, forecast openmeteo.ForecastResponse, ensemble openmeteo.EnsembleResponse, normals openmeteo.ClimateNormals, now time.Time, showPast bool) string {
 	// cw -> the width of the column without right margin
 	// mr -> the right margin for every column except the last
 	// width -> total available width
@@ -249,12 +468,19 @@ func renderDaily(width int, forecast openmeteo.ForecastResponse) string {
 		return theme.SubtleStyle.Render("The terminal window is too small")
 	}
 
-	// We need at least "today" + 1 future day to do anything useful.
-	if len(forecast.DailyTimes) < 2 {
+	// Start the day after today. We need at least 1 future day to do anything useful.
+	today := forecast.DailyTimes.IndexAt(now)
+	first := today + 1
+	if first >= len(forecast.DailyTimes) {
 		return theme.SubtleStyle.Render("Daily forecast unavailable")
 	}
-	dailySeries := forecast.DailyTimes[1:]
-	dayCount := len(dailySeries)
+	// The past days come with today, to compare them.
+	title := "Next few days"
+	if showPast && today > 0 {
+		first = 0
+		title = "Past and next few days"
+	}
+	dayCount := len(forecast.DailyTimes) - first
 
 	// Clamp max allowed to the available daily data series from the API response
 	maxAvailable := dayCount
@@ -262,75 +488,478 @@ func renderDaily(width int, forecast openmeteo.ForecastResponse) string {
 		maxAllowed = maxAvailable
 	}
 
-	var wmoSeries []float64
-	weatherCodes, hasCodes := forecast.DailySeries(openmeteo.DailyWeatherCode)
-	if hasCodes {
-		wmoSeries = weatherCodes.Values[1:]
+	cols := make([]string, 0, maxAllowed)
+	for i := range maxAllowed {
+		day := first + i
+		label := formatDailyDate(forecast.DailyTimes[day])
+		if day == today {
+			label = "Today"
+		}
+		cols = append(cols, renderDayColumn(label, forecast, day, ensemble, normals, day < today))
 	}
 
-	var minSeries []float64
-	minTemps, hasMin := forecast.DailySeries(openmeteo.DailyTemperature2mMin)
-	if hasMin {
-		minSeries = minTemps.Values[1:]
+	dailyColumns := joinColumns(cols, mr)
+	daily := lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render(title), dailyColumns)
+	return lipgloss.NewStyle().PaddingBottom(1).Render(daily)
+}
+
+// Renders the content of a daily column: the label, the conditions and the temperature range of the given day.
+// When the ensemble covers the day, the temperatures show the spread of its members instead.
+// With the climate normals, the difference between the high and the normal high follows.
+// Past days are greyed out.
+func renderDayColumn(label string, forecast openmeteo.ForecastResponse, day int, ensemble openmeteo.EnsembleResponse, normals openmeteo.ClimateNormals, past bool) string {
+	weatherCodes, _ := forecast.DailySeries(openmeteo.DailyWeatherCode)
+	minTemps, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMin)
+	maxTemps, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMax)
+
+	conditionsStyle := theme.AccentStyle
+	valueStyle := lipgloss.NewStyle()
+	if past {
+		conditionsStyle = theme.SubtleStyle
+		valueStyle = theme.SubtleStyle
 	}
 
-	var maxSeries []float64
-	maxTemps, hasMax := forecast.DailySeries(openmeteo.DailyTemperature2mMax)
-	if hasMax {
-		maxSeries = maxTemps.Values[1:]
+	dayStr := theme.SubtleStyle.Render(label)
+
+	wmoStr := "-"
+	if code, ok := weatherCodes.At(day); ok {
+		if mapped := openmeteo.MapWeatherCode(code); mapped != "" {
+			wmoStr = conditionsStyle.Render(mapped)
+		}
 	}
 
-	cols := make([]string, 0, maxAllowed)
-	for i := range maxAllowed {
-		dayStr := theme.SubtleStyle.Render(formatDailyDate(forecast.DailyTimes[i]))
+	minStr := "-"
+	if minTemp, ok := minTemps.At(day); ok {
+		minStr = formatValueWithUnit(minTemp, minTemps.Unit)
+	}
 
-		wmoStr := "-"
-		if hasCodes {
-			if mapped := openmeteo.MapWeatherCode(wmoSeries[i]); mapped != "" {
-				wmoStr = theme.AccentStyle.Render(mapped)
+	maxStr := "-"
+	if maxTemp, ok := maxTemps.At(day); ok {
+		maxStr = formatValueWithUnit(maxTemp, maxTemps.Unit)
+	}
+
+	if !past && day >= 0 && day < len(forecast.DailyTimes) {
+		member := ensemble.DailyTimes.IndexAt(forecast.DailyTimes[day])
+		if member >= 0 && ensemble.DailyTimes[member].Equal(forecast.DailyTimes[day]) {
+			if spread, ok := ensemble.DailySeries(openmeteo.DailyTemperature2mMin); ok {
+				minStr = formatSpread(spread, member, minStr)
+			}
+			if spread, ok := ensemble.DailySeries(openmeteo.DailyTemperature2mMax); ok {
+				maxStr = formatSpread(spread, member, maxStr)
 			}
 		}
+	}
 
-		minStr := "-"
-		if hasMin {
-			minStr = formatValueWithUnit(minSeries[i], minTemps.Unit)
+	minLabel := theme.LabelStyle.Render("Min")
+	maxLabel := theme.LabelStyle.Render("Max")
+
+	rows := []string{
+		dayStr,
+		wmoStr,
+		fmt.Sprintf("%s%s", minLabel, valueStyle.Render(minStr)),
+		fmt.Sprintf("%s%s", maxLabel, valueStyle.Render(maxStr)),
+	}
+	if maxTemp, ok := maxTemps.At(day); ok && day >= 0 && day < len(forecast.DailyTimes) {
+		if normal, ok := normals.At(forecast.DailyTimes[day]); ok {
+			anomaly := formatAnomaly(maxTemp-convertCelsius(normal.Max, maxTemps.Unit), false)
+			rows = append(rows, fmt.Sprintf("%s%s", theme.LabelStyle.Render("Vs normal"), theme.SubtleStyle.Render(anomaly)))
 		}
+	}
 
-		maxStr := "-"
-		if hasMax {
-			maxStr = formatValueWithUnit(maxSeries[i], maxTemps.Unit)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// Renders the daily highs, lows and conditions of the compared models, one
+// row per model and one column per day, with the spread of the highs last.
+func renderComparison(width int, forecasts map[openmeteo.WeatherModel]openmeteo.ForecastResponse, state dataState, now time.Time) string {
+	title := titleStyle.Render("Model comparison")
+	switch state {
+	case dataLoading:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Loading models..."))
+	case dataError:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Model comparison unavailable"))
+	}
+
+	cw := columnWidthStyle.GetWidth()
+	mr := 2
+	// One column is taken by the model names.
+	maxAllowed := (width+mr)/(cw+mr) - 1
+	if maxAllowed < 1 {
+		return theme.SubtleStyle.Render("The terminal window is too small")
+	}
+
+	// The models share the time steps, take them from any of them.
+	var days openmeteo.TimeAxis
+	for _, model := range comparisonModels {
+		if forecast, ok := forecasts[model]; ok && len(forecast.DailyTimes) > 0 {
+			days = forecast.DailyTimes
+			break
 		}
+	}
+	first := days.IndexAt(now) + 1
+	if first >= len(days) {
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Model comparison unavailable"))
+	}
+	maxAllowed = min(maxAllowed, len(days)-first)
 
-		minLabel := theme.LabelStyle.Render("Min")
-		maxLabel := theme.LabelStyle.Render("Max")
+	names := []string{""}
+	for _, model := range comparisonModels {
+		names = append(names, theme.LabelStyle.Render(model.Label()), "")
+	}
+	names = append(names, theme.LabelStyle.Render("Max spread"))
+	cols := []string{strings.Join(names, "\n")}
 
-		column := lipgloss.JoinVertical(
-			lipgloss.Left,
-			dayStr,
-			wmoStr,
-			fmt.Sprintf("%s%s", minLabel, minStr),
-			fmt.Sprintf("%s%s", maxLabel, maxStr),
-		)
+	for i := range maxAllowed {
+		day := first + i
+		lines := []string{theme.SubtleStyle.Render(formatDailyDate(days[day]))}
+		var highs []float64
+		var unit string
+		for _, model := range comparisonModels {
+			forecast := forecasts[model]
+			weatherCodes, _ := forecast.DailySeries(openmeteo.DailyWeatherCode)
+			minTemps, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMin)
+			maxTemps, _ := forecast.DailySeries(openmeteo.DailyTemperature2mMax)
+
+			conditions := "-"
+			if code, ok := weatherCodes.At(day); ok {
+				if mapped := openmeteo.MapWeatherCode(code); mapped != "" {
+					conditions = theme.AccentStyle.Render(mapped)
+				}
+			}
+			temperatures := "-"
+			low, okLow := minTemps.At(day)
+			high, okHigh := maxTemps.At(day)
+			if okLow && okHigh {
+				temperatures = fmt.Sprintf("%.0f / %.0f %s", low, high, maxTemps.Unit)
+			}
+			if okHigh {
+				highs = append(highs, high)
+				unit = maxTemps.Unit
+			}
+			lines = append(lines, conditions, temperatures)
+		}
+
+		spread := "-"
+		if len(highs) > 1 {
+			spread = formatValueWithUnit(slices.Max(highs)-slices.Min(highs), unit)
+		}
+		lines = append(lines, spread)
+		cols = append(cols, strings.Join(lines, "\n"))
+	}
+
+	comparison := lipgloss.JoinVertical(lipgloss.Left, title, joinColumns(cols, mr))
+	return lipgloss.NewStyle().PaddingBottom(1).Render(comparison)
+}
+
+// Lays out column contents side by side, separated by a border and a right margin of mr.
+func joinColumns(contents []string, mr int) string {
+	cols := make([]string, 0, len(contents))
+	for i, content := range contents {
 		style := columnWidthStyle
-		if i != maxAllowed-1 {
+		if i != len(contents)-1 {
 			style = style.Inherit(columnBorderStyle).MarginRight(mr)
 		}
-		column = style.Render(column)
-		cols = append(cols, column)
+		cols = append(cols, style.Render(content))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+}
+
+// Renders the current air quality with its category, the main pollutants,
+// the pollen species with data and the European AQI trend for the next hours.
+func renderAirQuality(width int, airQuality openmeteo.AirQualityResponse, state dataState, now time.Time) string {
+	title := titleStyle.Render("Air quality")
+	switch state {
+	case dataLoading:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Loading air quality..."))
+	case dataError:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Air quality unavailable"))
+	}
+
+	aqiLabel := theme.LabelStyle.Render("AQI")
+	aqiValue := "-"
+	if aqi, ok := airQuality.CurrentMeasurement(openmeteo.AirQualityEuropeanAQI); ok {
+		aqiValue = theme.AccentStyle.Render(fmt.Sprintf("%.0f %s", aqi.Value, openmeteo.MapEuropeanAQI(aqi.Value)))
+	}
+	if usAQI, ok := airQuality.CurrentMeasurement(openmeteo.AirQualityUSAQI); ok {
+		aqiValue += theme.SubtleStyle.Render(fmt.Sprintf("  (US %.0f %s)", usAQI.Value, openmeteo.MapUSAQI(usAQI.Value)))
+	}
+	aqiLine := aqiLabel + aqiValue
+
+	var pollutants string
+	for i, variable := range []openmeteo.AirQualityVariables{
+		openmeteo.AirQualityPM2_5,
+		openmeteo.AirQualityPM10,
+		openmeteo.AirQualityOzone,
+	} {
+		value := "-"
+		if measurement, ok := airQuality.CurrentMeasurement(variable); ok {
+			value = formatAirQualityMeasurement(measurement)
+		}
+		if i > 0 {
+			pollutants += "\n"
+		}
+		pollutants += theme.LabelStyle.Render(formatAirQualityVariable(variable)) + value
+	}
+	cols := []string{pollutants}
+
+	// Pollen is null outside of Europe and the season, only list the species with data.
+	var pollen []string
+	for _, variable := range openmeteo.PollenVariables {
+		if measurement, ok := airQuality.CurrentMeasurement(variable); ok {
+			pollen = append(pollen, theme.LabelStyle.Render(formatAirQualityVariable(variable))+formatAirQualityMeasurement(measurement))
+		}
+	}
+	for len(pollen) > 0 {
+		n := min(3, len(pollen))
+		cols = append(cols, strings.Join(pollen[:n], "\n"))
+		pollen = pollen[n:]
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, aqiLine, "", joinColumns(cols, 2))
+	if aqiSeries, ok := airQuality.HourlySeries(openmeteo.AirQualityEuropeanAQI); ok {
+		format := func(v float64) string { return fmt.Sprintf("%.0f", v) }
+		if trend := renderTrend(width, "Next hours", airQuality.HourlyTimes, aqiSeries, now, format); trend != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "", trend)
+		}
+	}
+	return lipgloss.NewStyle().PaddingBottom(1).Render(content)
+}
+
+// Renders a series for the coming hours on one line, as many steps as fit in the width.
+func renderTrend(width int, label string, times openmeteo.TimeAxis, series openmeteo.FloatSeries, now time.Time, format func(float64) string) string {
+	label = theme.LabelStyle.Render(label)
+	used := lipgloss.Width(label)
+	var steps []string
+	for hour := times.IndexAt(now) + 1; hour < len(times); hour++ {
+		value := "-"
+		if v, ok := series.At(hour); ok {
+			value = format(v)
+		}
+		step := fmt.Sprintf("%s %s", theme.SubtleStyle.Render(formatHourlyTime(times[hour])), value)
+		if used+lipgloss.Width(step)+3 > width {
+			break
+		}
+		used += lipgloss.Width(step) + 3
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return ""
+	}
+	return label + strings.Join(steps, theme.SubtleStyle.Render(" · "))
+}
+
+// Renders the current sea state and the wave height for the next hours.
+func renderMarine(width int, marine openmeteo.MarineResponse, now time.Time) string {
+	title := titleStyle.Render("Marine")
+
+	type row struct {
+		label    string
+		variable openmeteo.MarineVariables
+	}
+	column := func(rows ...row) string {
+		lines := make([]string, 0, len(rows))
+		for _, r := range rows {
+			value := "-"
+			if measurement, ok := marine.CurrentMeasurement(r.variable); ok {
+				value = formatMeasurement(measurement)
+			}
+			lines = append(lines, theme.LabelStyle.Render(r.label)+value)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	cols := []string{
+		column(
+			row{"Waves", openmeteo.MarineWaveHeight},
+			row{"Period", openmeteo.MarineWavePeriod},
+			row{"Direction", openmeteo.MarineWaveDirection},
+		),
+		column(
+			row{"Swell", openmeteo.MarineSwellWaveHeight},
+			row{"Period", openmeteo.MarineSwellWavePeriod},
+			row{"Direction", openmeteo.MarineSwellWaveDirection},
+		),
+		column(
+			row{"Sea temp", openmeteo.MarineSeaSurfaceTemperature},
+		),
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, title, joinColumns(cols, 2))
+	if waves, ok := marine.HourlySeries(openmeteo.MarineWaveHeight); ok {
+		format := func(v float64) string { return formatValueWithUnit(v, waves.Unit) }
+		if trend := renderTrend(width, "Next hours", marine.HourlyTimes, waves, now, format); trend != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "", trend)
+		}
+	}
+	return lipgloss.NewStyle().PaddingBottom(1).Render(content)
+}
+
+// Discharge in m³/s below which the grid cell is not taken for a river. The
+// Flood API answers for most land, with small values away from rivers.
+const minRiverDischarge = 5
+
+// Reports whether the discharge forecast is that of a river, see minRiverDischarge.
+func hasRiver(flood openmeteo.FloodResponse) bool {
+	if !flood.HasData() {
+		return false
+	}
+	for _, series := range flood.Daily {
+		for _, value := range series.Values {
+			if value >= minRiverDischarge {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Renders the river discharge today, its peak over the coming weeks and the
+// trend, every few days. Days where the median of the ensemble is above the
+// usual range of the season are flagged.
+func renderFlood(width int, flood openmeteo.FloodResponse, band openmeteo.DischargeBand, now time.Time) string {
+	title := titleStyle.Render("River discharge")
+	median, _ := flood.DailySeries(openmeteo.FloodRiverDischargeMedian)
+	maximum, _ := flood.DailySeries(openmeteo.FloodRiverDischargeMax)
+	times := flood.DailyTimes
+	today := max(times.IndexAt(now), 0)
+
+	above := func(series openmeteo.FloatSeries, day int) bool {
+		value, ok := series.At(day)
+		if !ok {
+			return false
+		}
+		_, high, ok := band.At(times[day])
+		return ok && value > high
+	}
+
+	todayValue := "-"
+	if value, ok := median.At(today); ok && today < len(times) {
+		todayValue = formatDischarge(value, median.Unit)
+		if low, high, ok := band.At(times[today]); ok {
+			todayValue += theme.SubtleStyle.Render(fmt.Sprintf(" (usually %s)", formatDischargeRange(low, high, band.Unit)))
+		}
+	}
+	lines := []string{theme.LabelStyle.Render("Today") + todayValue}
+
+	// The peak of the median, with the highest member of the ensemble.
+	peak, peakValue := -1, 0.0
+	for day := today; day < len(times); day++ {
+		if value, ok := median.At(day); ok && (peak < 0 || value > peakValue) {
+			peak, peakValue = day, value
+		}
+	}
+	if peak >= 0 {
+		peakStr := fmt.Sprintf("%s on %s", formatDischarge(peakValue, median.Unit), times[peak].Format("Jan 2"))
+		if highest, ok := maximum.At(peak); ok && highest > peakValue {
+			peakStr += theme.SubtleStyle.Render(fmt.Sprintf(" (up to %s)", formatDischarge(highest, maximum.Unit)))
+		}
+		lines = append(lines, theme.LabelStyle.Render("Peak")+peakStr)
+	}
+
+	// One step every few days, as many as fit in the width.
+	label := theme.LabelStyle.Render("Next weeks")
+	used := lipgloss.Width(label)
+	var steps []string
+	for day := today; day < len(times); day += 3 {
+		value := "-"
+		if v, ok := median.At(day); ok {
+			value = formatDischarge(v, "")
+			if above(median, day) {
+				value = theme.AccentStyle.Render(value + " ▲")
+			}
+		}
+		step := fmt.Sprintf("%s %s", theme.SubtleStyle.Render(times[day].Format("Jan 2")), value)
+		if used+lipgloss.Width(step)+3 > width {
+			break
+		}
+		used += lipgloss.Width(step) + 3
+		steps = append(steps, step)
+	}
+	if len(steps) > 0 {
+		lines = append(lines, label+strings.Join(steps, theme.SubtleStyle.Render(" · ")))
+	}
+
+	// Flag the first day above the usual range.
+	if len(band.High) > 0 {
+		first := func(series openmeteo.FloatSeries) int {
+			for day := today; day < len(times); day++ {
+				if above(series, day) {
+					return day
+				}
+			}
+			return -1
+		}
+		var summary string
+		if day := first(median); day >= 0 {
+			summary = theme.AccentStyle.Render(fmt.Sprintf("Above the usual range from %s, higher than 9 in 10 years of %s", times[day].Format("Jan 2"), band.Period))
+		} else if day := first(maximum); day >= 0 {
+			summary = theme.SubtleStyle.Render(fmt.Sprintf("Some ensemble members go above the usual range from %s", times[day].Format("Jan 2")))
+		} else {
+			summary = theme.SubtleStyle.Render(fmt.Sprintf("Within the usual range of the season (%s)", band.Period))
+		}
+		lines = append(lines, "", summary)
 	}
 
-	dailyColumns := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
-	daily := lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render("Next few days"), dailyColumns)
-	return lipgloss.NewStyle().Render(daily)
+	content := lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(lines, "\n"))
+	return lipgloss.NewStyle().PaddingBottom(1).Render(content)
 }
 
-func renderBody(width int, header, current, currentDetails, hourly, daily string) string {
-	header = renderSection(width, header, false)
-	current = renderSection(width, current, false)
-	currentDetails = renderSection(width, currentDetails, true)
-	hourly = renderSection(width, hourly, true)
-	daily = renderSection(width, daily, false)
-	return lipgloss.JoinVertical(lipgloss.Left, header, current, currentDetails, hourly, daily)
+// Renders the weather for the same day last year and the past week. The number of past days rendered depends on the available width.
+func renderHistory(width int, history historyMsg, state dataState) string {
+	title := titleStyle.Render("Recent history")
+	switch state {
+	case dataLoading:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("Loading history..."))
+	case dataError:
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("History unavailable"))
+	}
+
+	cw := columnWidthStyle.GetWidth()
+	mr := 2
+	maxAllowed := (width + mr) / (cw + mr)
+	if maxAllowed < 1 {
+		return theme.SubtleStyle.Render("The terminal window is too small")
+	}
+
+	cols := make([]string, 0, maxAllowed)
+	if len(history.lastYear.DailyTimes) > 0 {
+		label := history.lastYear.DailyTimes[0].Format("Jan 2") + ", last year"
+		cols = append(cols, renderDayColumn(label, history.lastYear, 0, openmeteo.EnsembleResponse{}, openmeteo.ClimateNormals{}, false))
+	}
+
+	// Keep the most recent days when the window cannot fit the whole week.
+	pastDays := history.pastWeek.DailyTimes
+	first := max(len(pastDays)-(maxAllowed-len(cols)), 0)
+	for day := first; day < len(pastDays); day++ {
+		cols = append(cols, renderDayColumn(formatDailyDate(pastDays[day]), history.pastWeek, day, openmeteo.EnsembleResponse{}, openmeteo.ClimateNormals{}, false))
+	}
+	if len(cols) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, title, theme.SubtleStyle.Render("History unavailable"))
+	}
+
+	historyColumns := joinColumns(cols, mr)
+	return lipgloss.JoinVertical(lipgloss.Left, title, historyColumns)
+}
+
+// Stacks the header and current conditions above the panels. Panels are
+// separated by dividers, empty ones are skipped.
+func renderBody(width int, header, current string, panels ...string) string {
+	sections := []string{
+		renderSection(width, header, false),
+		renderSection(width, current, false),
+	}
+
+	visible := make([]string, 0, len(panels))
+	for _, panel := range panels {
+		if panel != "" {
+			visible = append(visible, panel)
+		}
+	}
+	for i, panel := range visible {
+		sections = append(sections, renderSection(width, panel, i != len(visible)-1))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
 func renderSection(width int, content string, withDivider bool) string {